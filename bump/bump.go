@@ -0,0 +1,233 @@
+package bump
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	JSON  = "json"
+	XML   = "xml"
+	YAML  = "yaml"
+	TOML  = "toml"
+	REGEX = "regex"
+	PLAIN = "plain"
+)
+
+type builtInFile struct {
+	fileType  string
+	selectors []string
+}
+
+// Built-in updaters by file name. Only selectors, which exist in a file, are updated.
+var builtInFiles = map[string]builtInFile{
+	"package.json":   {JSON, []string{"$.version"}},
+	"pom.xml":        {XML, []string{"/project/version"}},
+	"Chart.yaml":     {YAML, []string{"version", "appVersion"}},
+	"Cargo.toml":     {TOML, []string{"package.version", "workspace.package.version"}},
+	"pyproject.toml": {TOML, []string{"project.version", "tool.poetry.version"}},
+	"VERSION":        {PLAIN, nil},
+}
+
+type FileResult struct {
+	// Path is relative to the root of the repository
+	Path string
+	// PreviousVersions are the versions, which were found in the file before the update
+	PreviousVersions []string
+	PreviousContent  string
+	Content          string
+}
+
+func (r *FileResult) Changed() bool {
+	return r.PreviousContent != r.Content
+}
+
+type BumpOptions struct {
+	Workdir string
+	// Files to update. Built-in files in the root of the repository are detected if it is empty.
+	Files   []config.BumpFile
+	Version string
+	// DryRun only plans the changes without writing them
+	DryRun bool
+}
+
+// Updates the version in all files and returns the results for all files.
+func Bump(options BumpOptions) ([]*FileResult, error) {
+
+	root, err := config.RepositoryRoot(options.Workdir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := options.Files
+
+	if len(files) == 0 {
+		files = DetectFiles(root)
+	}
+
+	results, err := Plan(root, files, options.Version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun {
+		return results, nil
+	}
+
+	return results, Write(root, results)
+}
+
+// Returns all built-in files, which exist in the root directory.
+func DetectFiles(root string) []config.BumpFile {
+
+	var files []config.BumpFile
+
+	for name := range builtInFiles {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			files = append(files, config.BumpFile{Path: name})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files
+}
+
+// Calculates the new content of all files with the version, but does not write them.
+func Plan(root string, files []config.BumpFile, version string) ([]*FileResult, error) {
+
+	var results []*FileResult
+
+	for _, file := range files {
+
+		content, err := os.ReadFile(filepath.Join(root, file.Path))
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not read "+file.Path)
+		}
+
+		locate, err := fileLocator(file)
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Invalid configuration for "+file.Path)
+		}
+
+		spans, err := locate(string(content))
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not find version in "+file.Path)
+		}
+
+		if len(spans) == 0 {
+			return nil, errors.New("Could not find version in " + file.Path)
+		}
+
+		result := &FileResult{
+			Path:            file.Path,
+			PreviousContent: string(content),
+			Content:         replaceSpans(string(content), spans, version),
+		}
+
+		for _, s := range spans {
+			result.PreviousVersions = append(result.PreviousVersions, string(content[s.start:s.end]))
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Writes all changed files.
+func Write(root string, results []*FileResult) error {
+
+	for _, result := range results {
+		if !result.Changed() {
+			continue
+		}
+
+		if err := os.WriteFile(filepath.Join(root, result.Path), []byte(result.Content), 0666); err != nil {
+			return errors.WithMessage(err, "Could not write "+result.Path)
+		}
+	}
+
+	return nil
+}
+
+func fileLocator(file config.BumpFile) (locator, error) {
+
+	fileType := file.Type
+	selectors := file.Selectors
+
+	if builtIn, ok := builtInFiles[filepath.Base(file.Path)]; ok && (fileType == "" || fileType == builtIn.fileType) {
+		fileType = builtIn.fileType
+
+		if len(selectors) == 0 {
+			selectors = builtIn.selectors
+		}
+	}
+
+	var newLocator func(selector string) (locator, error)
+
+	switch fileType {
+	case PLAIN:
+		return plainLocator, nil
+	case REGEX:
+		return regexLocator(file.Pattern)
+	case JSON:
+		newLocator = jsonLocator
+	case XML:
+		newLocator = xmlLocator
+	case YAML:
+		newLocator = yamlLocator
+	case TOML:
+		newLocator = tomlLocator
+	case "":
+		return nil, errors.New("Unknown file. Please specify a type.")
+	default:
+		return nil, errors.Errorf("Unknown type %s", fileType)
+	}
+
+	if len(selectors) == 0 {
+		return nil, errors.Errorf("Type %s requires selectors", fileType)
+	}
+
+	var locators []locator
+
+	for _, selector := range selectors {
+		l, err := newLocator(selector)
+
+		if err != nil {
+			return nil, err
+		}
+
+		locators = append(locators, l)
+	}
+
+	return func(content string) ([]span, error) {
+		var spans []span
+
+		for _, l := range locators {
+			s, err := l(content)
+
+			if err != nil {
+				return nil, err
+			}
+
+			spans = append(spans, s...)
+		}
+
+		sort.Slice(spans, func(i, j int) bool {
+			return spans[i].start < spans[j].start
+		})
+
+		return spans, nil
+	}, nil
+}
@@ -0,0 +1,189 @@
+package bump
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func planSingleFile(t *testing.T, file config.BumpFile, content string) *FileResult {
+	root := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(root, file.Path)), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, file.Path), []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Plan(root, []config.BumpFile{file}, "1.4.0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return results[0]
+}
+
+func TestPlan_should_update_package_json(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "package.json"}, `{
+  "name": "example",
+  "version": "1.3.0",
+  "dependencies": {
+    "other": {"version": "0.1.0"}
+  }
+}
+`)
+
+	assert.Equal(t, []string{"1.3.0"}, result.PreviousVersions)
+	assert.Equal(t, `{
+  "name": "example",
+  "version": "1.4.0",
+  "dependencies": {
+    "other": {"version": "0.1.0"}
+  }
+}
+`, result.Content)
+}
+
+func TestPlan_should_update_pom_xml(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "pom.xml"}, `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <parent>
+        <version>2.0.0</version>
+    </parent>
+    <version>1.3.0</version>
+    <dependencies>
+        <dependency>
+            <version>3.0.0</version>
+        </dependency>
+    </dependencies>
+</project>
+`)
+
+	assert.Equal(t, []string{"1.3.0"}, result.PreviousVersions)
+	assert.Contains(t, result.Content, "<version>1.4.0</version>\n    <dependencies>")
+	assert.Contains(t, result.Content, "<version>2.0.0</version>")
+	assert.Contains(t, result.Content, "<version>3.0.0</version>")
+}
+
+func TestPlan_should_update_version_and_app_version_in_chart_yaml(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "charts/app/Chart.yaml"}, `apiVersion: v2
+name: app
+version: 1.3.0 # chart version
+appVersion: "1.3.0"
+dependencies:
+  - name: other
+    version: 0.1.0
+`)
+
+	assert.Equal(t, []string{"1.3.0", "1.3.0"}, result.PreviousVersions)
+	assert.Equal(t, `apiVersion: v2
+name: app
+version: 1.4.0 # chart version
+appVersion: "1.4.0"
+dependencies:
+  - name: other
+    version: 0.1.0
+`, result.Content)
+}
+
+func TestPlan_should_update_cargo_toml(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "Cargo.toml"}, `[package]
+name = "example"
+version = "1.3.0"
+
+[dependencies]
+other = { version = "0.1.0" }
+`)
+
+	assert.Equal(t, `[package]
+name = "example"
+version = "1.4.0"
+
+[dependencies]
+other = { version = "0.1.0" }
+`, result.Content)
+}
+
+func TestPlan_should_update_pyproject_toml(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "pyproject.toml"}, `[tool.poetry]
+name = 'example'
+version = '1.3.0'
+`)
+
+	assert.Equal(t, "[tool.poetry]\nname = 'example'\nversion = '1.4.0'\n", result.Content)
+}
+
+func TestPlan_should_update_plain_version_file(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{Path: "VERSION"}, "1.3.0\n")
+
+	assert.Equal(t, "1.4.0\n", result.Content)
+}
+
+func TestPlan_should_update_file_with_regex(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{
+		Path:    "src/version.go",
+		Type:    REGEX,
+		Pattern: `const Version = "(?P<version>[^"]+)"`,
+	}, "package src\n\nconst Version = \"1.3.0\"\n")
+
+	assert.Equal(t, "package src\n\nconst Version = \"1.4.0\"\n", result.Content)
+}
+
+func TestPlan_should_update_file_with_json_path(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{
+		Path:      "package-lock.json",
+		Type:      JSON,
+		Selectors: []string{"$.version", `$.packages[""].version`},
+	}, `{"version": "1.3.0", "packages": {"": {"version": "1.3.0"}, "node_modules/x": {"version": "1.3.0"}}}`)
+
+	assert.Equal(t, `{"version": "1.4.0", "packages": {"": {"version": "1.4.0"}, "node_modules/x": {"version": "1.3.0"}}}`, result.Content)
+}
+
+func TestPlan_should_update_file_with_xml_path(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{
+		Path:      "descriptor.xml",
+		Type:      XML,
+		Selectors: []string{"/descriptor/app/version"},
+	}, "<descriptor><app><version>\n  1.3.0\n</version></app></descriptor>")
+
+	assert.Equal(t, "<descriptor><app><version>\n  1.4.0\n</version></app></descriptor>", result.Content)
+}
+
+func TestPlan_should_update_nested_yaml_key(t *testing.T) {
+	result := planSingleFile(t, config.BumpFile{
+		Path:      "values.yaml",
+		Type:      YAML,
+		Selectors: []string{"image.tag"},
+	}, "tag: latest\nimage:\n  repository: example\n  tag: '1.3.0'\n")
+
+	assert.Equal(t, "tag: latest\nimage:\n  repository: example\n  tag: '1.4.0'\n", result.Content)
+}
+
+func TestPlan_should_fail_if_version_is_missing(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name": "example"}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Plan(root, []config.BumpFile{{Path: "package.json"}}, "1.4.0")
+
+	assert.EqualError(t, err, "Could not find version in package.json")
+}
+
+func TestPlan_should_fail_for_unknown_files_without_type(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "version.txt"), []byte("1.3.0"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Plan(root, []config.BumpFile{{Path: "version.txt"}}, "1.4.0")
+
+	assert.EqualError(t, err, "Invalid configuration for version.txt: Unknown file. Please specify a type.")
+}
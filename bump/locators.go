@@ -0,0 +1,424 @@
+package bump
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/pkg/errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// span is the byte range of a version in the content of a file
+type span struct {
+	start int
+	end   int
+}
+
+// locator finds the byte ranges of all versions in the content of a file
+type locator func(content string) ([]span, error)
+
+func plainLocator(content string) ([]span, error) {
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+	start := len(content) - len(trimmed)
+	end := start + len(strings.TrimRight(trimmed, " \t\r\n"))
+
+	return []span{{start, end}}, nil
+}
+
+func regexLocator(pattern string) (locator, error) {
+
+	regex, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not compile pattern")
+	}
+
+	group := regex.SubexpIndex("version")
+
+	if group < 0 {
+		group = 1
+	}
+
+	if regex.NumSubexp() < group {
+		return nil, errors.Errorf("Pattern %s has no group matching the version", pattern)
+	}
+
+	return func(content string) ([]span, error) {
+		var spans []span
+
+		for _, match := range regex.FindAllStringSubmatchIndex(content, -1) {
+			if match[2*group] >= 0 {
+				spans = append(spans, span{match[2*group], match[2*group+1]})
+			}
+		}
+
+		return spans, nil
+	}, nil
+}
+
+// Supports selectors like "$.version", "$.packages[\"\"].version" or "$.items[0].version".
+func jsonLocator(selector string) (locator, error) {
+
+	path, err := parseJsonPath(selector)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(content string) ([]span, error) {
+
+		type frame struct {
+			array        bool
+			key          string
+			index        int
+			expectingKey bool
+		}
+
+		var stack []*frame
+		var spans []span
+
+		currentPath := func() []string {
+			var ret []string
+
+			for _, f := range stack {
+				if f.array {
+					ret = append(ret, strconv.Itoa(f.index))
+				} else {
+					ret = append(ret, f.key)
+				}
+			}
+
+			return ret
+		}
+
+		afterValue := func() {
+			if len(stack) == 0 {
+				return
+			}
+
+			top := stack[len(stack)-1]
+
+			if top.array {
+				top.index++
+			} else {
+				top.expectingKey = true
+			}
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(content))
+
+		for {
+			offset := int(decoder.InputOffset())
+			token, err := decoder.Token()
+
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, errors.WithMessage(err, "Could not parse json")
+			}
+
+			if len(stack) > 0 && !stack[len(stack)-1].array && stack[len(stack)-1].expectingKey {
+				if token == json.Delim('}') {
+					stack = stack[:len(stack)-1]
+					afterValue()
+				} else {
+					stack[len(stack)-1].key = token.(string)
+					stack[len(stack)-1].expectingKey = false
+				}
+
+				continue
+			}
+
+			switch token {
+			case json.Delim(']'):
+				stack = stack[:len(stack)-1]
+				afterValue()
+			case json.Delim('{'):
+				stack = append(stack, &frame{expectingKey: true})
+			case json.Delim('['):
+				stack = append(stack, &frame{array: true})
+			default:
+				if _, isString := token.(string); isString && pathEquals(currentPath(), path) {
+					quote := offset + strings.IndexByte(content[offset:], '"')
+					spans = append(spans, span{quote + 1, int(decoder.InputOffset()) - 1})
+				}
+
+				afterValue()
+			}
+		}
+
+		return spans, nil
+	}, nil
+}
+
+func parseJsonPath(selector string) ([]string, error) {
+
+	if !strings.HasPrefix(selector, "$") {
+		return nil, errors.Errorf("JSON path %s has to start with $", selector)
+	}
+
+	var path []string
+
+	rest := selector[1:]
+
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+
+			if end < 0 {
+				end = len(rest) - 1
+			}
+
+			path = append(path, rest[1:end+1])
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+
+			if end < 0 {
+				return nil, errors.Errorf("Invalid JSON path %s", selector)
+			}
+
+			key := rest[1:end]
+
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			}
+
+			path = append(path, key)
+			rest = rest[end+1:]
+		default:
+			return nil, errors.Errorf("Invalid JSON path %s", selector)
+		}
+	}
+
+	return path, nil
+}
+
+// Supports absolute paths of element names like "/project/version".
+func xmlLocator(selector string) (locator, error) {
+
+	if !strings.HasPrefix(selector, "/") {
+		return nil, errors.Errorf("XML path %s has to start with /", selector)
+	}
+
+	path := strings.Split(selector[1:], "/")
+
+	return func(content string) ([]span, error) {
+
+		var stack []string
+		var spans []span
+		start := -1
+
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		decoder.Strict = false
+
+		for {
+			offset := int(decoder.InputOffset())
+			token, err := decoder.RawToken()
+
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, errors.WithMessage(err, "Could not parse xml")
+			}
+
+			switch t := token.(type) {
+			case xml.StartElement:
+				stack = append(stack, t.Name.Local)
+				start = -1
+
+				if pathEquals(stack, path) {
+					start = int(decoder.InputOffset())
+				}
+			case xml.EndElement:
+				if start >= 0 && pathEquals(stack, path) {
+					value := content[start:offset]
+					trimmedStart := start + len(value) - len(strings.TrimLeft(value, " \t\r\n"))
+					spans = append(spans, span{trimmedStart, trimmedStart + len(strings.TrimSpace(value))})
+				}
+
+				start = -1
+
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+
+		return spans, nil
+	}, nil
+}
+
+var yamlKeyRegex = regexp.MustCompile(`^(?P<Indent> *)(?P<Key>"[^"]*"|'[^']*'|[^\s:#][^:#]*?) *:(?P<Value>.*)$`)
+var yamlValueRegex = regexp.MustCompile(`^ *("(?P<DoubleQuoted>[^"]*)"|'(?P<SingleQuoted>[^']*)'|(?P<Plain>[^\s#'"][^#]*?)) *(#.*)?$`)
+
+// Supports dotted paths of mapping keys like "version" or "image.tag".
+func yamlLocator(selector string) (locator, error) {
+
+	path := strings.Split(selector, ".")
+
+	return func(content string) ([]span, error) {
+
+		type entry struct {
+			key    string
+			indent int
+		}
+
+		var stack []entry
+		var spans []span
+
+		lineStart := 0
+
+		for _, line := range strings.SplitAfter(content, "\n") {
+			offset := lineStart
+			lineStart += len(line)
+
+			line = strings.TrimRight(line, "\r\n")
+
+			match := yamlKeyRegex.FindStringSubmatchIndex(line)
+
+			if match == nil {
+				continue
+			}
+
+			indent := match[3] - match[2]
+			key := strings.Trim(line[match[4]:match[5]], `"'`)
+			valueStart := match[6]
+
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+
+			value := line[valueStart:]
+
+			if strings.TrimSpace(value) == "" || strings.HasPrefix(strings.TrimSpace(value), "#") {
+				stack = append(stack, entry{key, indent})
+				continue
+			}
+
+			keyPath := make([]string, 0, len(stack)+1)
+
+			for _, e := range stack {
+				keyPath = append(keyPath, e.key)
+			}
+
+			if !pathEquals(append(keyPath, key), path) {
+				continue
+			}
+
+			valueMatch := yamlValueRegex.FindStringSubmatchIndex(value)
+
+			if valueMatch == nil {
+				continue
+			}
+
+			for _, group := range []string{"DoubleQuoted", "SingleQuoted", "Plain"} {
+				index := yamlValueRegex.SubexpIndex(group)
+
+				if valueMatch[2*index] >= 0 {
+					spans = append(spans, span{offset + valueStart + valueMatch[2*index], offset + valueStart + valueMatch[2*index+1]})
+					break
+				}
+			}
+		}
+
+		return spans, nil
+	}, nil
+}
+
+var tomlTableRegex = regexp.MustCompile(`^\s*\[\[?\s*(?P<Table>[^\]]+?)\s*\]\]?\s*(#.*)?$`)
+var tomlKeyValueRegex = regexp.MustCompile(`^\s*(?P<Key>[A-Za-z0-9_\-."' ]+?)\s*=\s*("(?P<DoubleQuoted>[^"]*)"|'(?P<SingleQuoted>[^']*)')\s*(#.*)?$`)
+
+// Supports dotted keys like "package.version" or "tool.poetry.version".
+func tomlLocator(selector string) (locator, error) {
+
+	return func(content string) ([]span, error) {
+
+		var spans []span
+		table := ""
+		lineStart := 0
+
+		for _, line := range strings.SplitAfter(content, "\n") {
+			offset := lineStart
+			lineStart += len(line)
+
+			line = strings.TrimRight(line, "\r\n")
+
+			if match := tomlTableRegex.FindStringSubmatch(line); match != nil {
+				table = normalizeTomlKey(match[1])
+				continue
+			}
+
+			match := tomlKeyValueRegex.FindStringSubmatchIndex(line)
+
+			if match == nil {
+				continue
+			}
+
+			key := normalizeTomlKey(line[match[2]:match[3]])
+
+			if table != "" {
+				key = table + "." + key
+			}
+
+			if key != selector {
+				continue
+			}
+
+			for _, group := range []string{"DoubleQuoted", "SingleQuoted"} {
+				index := tomlKeyValueRegex.SubexpIndex(group)
+
+				if match[2*index] >= 0 {
+					spans = append(spans, span{offset + match[2*index], offset + match[2*index+1]})
+					break
+				}
+			}
+		}
+
+		return spans, nil
+	}, nil
+}
+
+func normalizeTomlKey(key string) string {
+	parts := strings.Split(key, ".")
+
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func pathEquals(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func replaceSpans(content string, spans []span, value string) string {
+	var buffer bytes.Buffer
+
+	last := 0
+
+	for _, s := range spans {
+		buffer.WriteString(content[last:s.start])
+		buffer.WriteString(value)
+		last = s.end
+	}
+
+	buffer.WriteString(content[last:])
+
+	return buffer.String()
+}
@@ -0,0 +1,310 @@
+package changelog
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/semver"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type Style string
+
+const (
+	// https://keepachangelog.com/en/1.1.0/
+	KEEP_A_CHANGELOG Style = "keepachangelog"
+	// https://github.com/conventional-changelog/conventional-changelog
+	CONVENTIONAL_CHANGELOG Style = "conventional"
+)
+
+const DATE_FORMAT = "2006-01-02"
+
+// UNRELEASED is the label of the section with the unreleased changes
+const UNRELEASED = "Unreleased"
+
+var sectionHeaderRegex = regexp.MustCompile(`^#{2,3} +\[?(?P<Version>v?\d+\.\d+\.\d+[^\]\s)]*|(?i:unreleased))\]?`)
+var linkDefinitionRegex = regexp.MustCompile(`^\[(?P<Label>[^\]]+)\]: *\S+`)
+
+var defaultPreambles = map[Style]string{
+	KEEP_A_CHANGELOG: `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).`,
+	CONVENTIONAL_CHANGELOG: `# Changelog`,
+}
+
+// Section describes the release notes of a single version
+type Section struct {
+	// Version is nil for the "Unreleased" section
+	Version *semver.Version
+	// Date is omitted if it is zero
+	Date time.Time
+	// CompareURL links the changes between the previous and this version. It is omitted if it is empty.
+	CompareURL string
+	// Content is the markdown body of the section without version header
+	Content string
+}
+
+// Changelog is a parsed changelog file
+type Changelog struct {
+	// Preamble is everything before the first version section (e.g. title and introduction)
+	Preamble string
+	Sections []*ParsedSection
+	// LinkDefinitions are the markdown link reference definitions at the end of the file (e.g. "[1.0.0]: https://...")
+	LinkDefinitions []string
+	// LineEnding is the line ending of the file, which is used by String(): "\n" (default) or "\r\n"
+	LineEnding string
+}
+
+type ParsedSection struct {
+	// Version is nil for sections like "Unreleased"
+	Version *semver.Version
+	// Label is the version or name in the header of the section
+	Label string
+	// Text contains the complete section including its header
+	Text string
+}
+
+func ParseStyle(style string) (Style, error) {
+	switch Style(style) {
+	case KEEP_A_CHANGELOG, CONVENTIONAL_CHANGELOG:
+		return Style(style), nil
+	}
+
+	return "", errors.Errorf("Unknown changelog style \"%s\" (expected %s or %s)", style, KEEP_A_CHANGELOG, CONVENTIONAL_CHANGELOG)
+}
+
+// Parses the content of a changelog file. The line ending of the file is determined by its first line.
+func Parse(content string) *Changelog {
+
+	changelog := &Changelog{}
+
+	if firstLine, _, found := strings.Cut(content, "\n"); found && strings.HasSuffix(firstLine, "\r") {
+		changelog.LineEnding = "\r\n"
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	// link definitions at the end of the file
+	end := len(lines)
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+
+		if line == "" {
+			continue
+		}
+
+		if !linkDefinitionRegex.MatchString(line) {
+			break
+		}
+
+		changelog.LinkDefinitions = append([]string{line}, changelog.LinkDefinitions...)
+		end = i
+	}
+
+	var current *ParsedSection
+	var currentLines []string
+	inCodeBlock := false
+
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(currentLines, "\n"))
+
+		if current == nil {
+			changelog.Preamble = text
+		} else {
+			current.Text = text
+			changelog.Sections = append(changelog.Sections, current)
+		}
+	}
+
+	for _, line := range lines[:end] {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+		}
+
+		match := sectionHeaderRegex.FindStringSubmatch(line)
+
+		if inCodeBlock || match == nil {
+			currentLines = append(currentLines, line)
+			continue
+		}
+
+		flush()
+
+		label := match[sectionHeaderRegex.SubexpIndex("Version")]
+		version, _ := semver.ParseVersion(label)
+
+		current = &ParsedSection{Version: version, Label: strings.TrimPrefix(label, "v")}
+		currentLines = []string{line}
+	}
+
+	flush()
+
+	return changelog
+}
+
+// Inserts the section in front of the section of the next lower version or replaces the section of the same version.
+func (c *Changelog) Upsert(section Section, style Style) {
+
+	newSection := &ParsedSection{
+		Version: section.Version,
+		Label:   section.Version.ToString(),
+		Text:    renderSection(section, style),
+	}
+
+	if style == KEEP_A_CHANGELOG && section.CompareURL != "" {
+		c.setLinkDefinition(section.Version, newSection.Label, section.CompareURL)
+	}
+
+	for i, s := range c.Sections {
+		if s.Version == nil {
+			continue
+		}
+
+		comparison := semver.CompareVersions(section.Version, s.Version)
+
+		if comparison == 0 {
+			c.Sections[i] = newSection
+			return
+		}
+
+		if comparison > 0 {
+			c.Sections = append(c.Sections[:i], append([]*ParsedSection{newSection}, c.Sections[i:]...)...)
+			return
+		}
+	}
+
+	c.Sections = append(c.Sections, newSection)
+}
+
+func (c *Changelog) setLinkDefinition(version *semver.Version, label string, url string) {
+	definition := "[" + label + "]: " + url
+
+	for i, d := range c.LinkDefinitions {
+		if linkLabel(d) == label {
+			c.LinkDefinitions[i] = definition
+			return
+		}
+	}
+
+	// keep the link definitions ordered like the sections
+	for i, d := range c.LinkDefinitions {
+		v, err := semver.ParseVersion(linkLabel(d))
+
+		if err == nil && semver.CompareVersions(version, v) > 0 {
+			c.LinkDefinitions = append(c.LinkDefinitions[:i], append([]string{definition}, c.LinkDefinitions[i:]...)...)
+			return
+		}
+	}
+
+	c.LinkDefinitions = append(c.LinkDefinitions, definition)
+}
+
+func linkLabel(linkDefinition string) string {
+	return linkDefinitionRegex.FindStringSubmatch(linkDefinition)[1]
+}
+
+func (c *Changelog) String() string {
+	var parts []string
+
+	if c.Preamble != "" {
+		parts = append(parts, c.Preamble)
+	}
+
+	for _, s := range c.Sections {
+		parts = append(parts, s.Text)
+	}
+
+	if len(c.LinkDefinitions) > 0 {
+		parts = append(parts, strings.Join(c.LinkDefinitions, "\n"))
+	}
+
+	text := strings.Join(parts, "\n\n") + "\n"
+
+	if c.LineEnding != "" && c.LineEnding != "\n" {
+		// templates may already render CRLF line endings
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", c.LineEnding)
+	}
+
+	return text
+}
+
+// Inserts or replaces the section in the content of a changelog file. A new changelog file gets a default preamble.
+func Update(content string, section Section, style Style) string {
+
+	changelog := Parse(content)
+
+	if strings.TrimSpace(content) == "" {
+		changelog.Preamble = defaultPreambles[style]
+	}
+
+	changelog.Upsert(section, style)
+
+	return changelog.String()
+}
+
+// Renders a complete changelog with a default preamble and the sections in the given order.
+func Render(sections []Section, style Style) string {
+
+	changelog := &Changelog{Preamble: defaultPreambles[style]}
+
+	for _, section := range sections {
+		label := UNRELEASED
+
+		if section.Version != nil {
+			label = section.Version.ToString()
+		}
+
+		changelog.Sections = append(changelog.Sections, &ParsedSection{
+			Version: section.Version,
+			Label:   label,
+			Text:    renderSection(section, style),
+		})
+
+		if style == KEEP_A_CHANGELOG && section.CompareURL != "" {
+			changelog.LinkDefinitions = append(changelog.LinkDefinitions, "["+label+"]: "+section.CompareURL)
+		}
+	}
+
+	return changelog.String()
+}
+
+func renderSection(section Section, style Style) string {
+	version := UNRELEASED
+
+	if section.Version != nil {
+		version = section.Version.ToString()
+	}
+
+	var header string
+
+	switch style {
+	case CONVENTIONAL_CHANGELOG:
+		if section.CompareURL != "" {
+			header = "## [" + version + "](" + section.CompareURL + ")"
+		} else {
+			header = "## " + version
+		}
+
+		if !section.Date.IsZero() {
+			header += " (" + section.Date.Format(DATE_FORMAT) + ")"
+		}
+	default:
+		header = "## [" + version + "]"
+
+		if !section.Date.IsZero() {
+			header += " - " + section.Date.Format(DATE_FORMAT)
+		}
+	}
+
+	content := strings.TrimSpace(section.Content)
+
+	if content == "" {
+		return header
+	}
+
+	return header + "\n\n" + content
+}
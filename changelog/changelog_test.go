@@ -0,0 +1,183 @@
+package changelog
+
+import (
+	"github.com/psanetra/git-semver/semver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var date = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func mustParseVersion(t *testing.T, str string) *semver.Version {
+	version, err := semver.ParseVersion(str)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return version
+}
+
+func TestUpdate_should_create_new_keep_a_changelog_file(t *testing.T) {
+	result := Update("", Section{
+		Version: mustParseVersion(t, "1.4.0"),
+		Date:    date,
+		Content: "### Features\n\n* Add feature\n",
+	}, KEEP_A_CHANGELOG)
+
+	assert.Equal(t, `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+## [1.4.0] - 2026-10-18
+
+### Features
+
+* Add feature
+`, result)
+}
+
+func TestUpdate_should_insert_section_at_the_right_place(t *testing.T) {
+	existing := `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2026-10-01
+
+* Two
+
+## [1.3.0] - 2026-09-01
+
+* One
+
+[unreleased]: https://example.com/compare/v2.0.0...HEAD
+[2.0.0]: https://example.com/compare/v1.3.0...v2.0.0
+[1.3.0]: https://example.com/compare/v1.2.0...v1.3.0
+`
+
+	result := Update(existing, Section{
+		Version:    mustParseVersion(t, "1.4.0"),
+		Date:       date,
+		CompareURL: "https://example.com/compare/v1.3.0...v1.4.0",
+		Content:    "* Backport",
+	}, KEEP_A_CHANGELOG)
+
+	assert.Equal(t, `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2026-10-01
+
+* Two
+
+## [1.4.0] - 2026-10-18
+
+* Backport
+
+## [1.3.0] - 2026-09-01
+
+* One
+
+[unreleased]: https://example.com/compare/v2.0.0...HEAD
+[2.0.0]: https://example.com/compare/v1.3.0...v2.0.0
+[1.4.0]: https://example.com/compare/v1.3.0...v1.4.0
+[1.3.0]: https://example.com/compare/v1.2.0...v1.3.0
+`, result)
+}
+
+func TestUpdate_should_replace_section_of_the_same_version(t *testing.T) {
+	existing := `# Changelog
+
+## 1.4.0 (2026-10-17)
+
+* Old
+
+## 1.3.0 (2026-09-01)
+
+* One
+`
+
+	section := Section{
+		Version: mustParseVersion(t, "1.4.0"),
+		Date:    date,
+		Content: "* New",
+	}
+
+	result := Update(existing, section, CONVENTIONAL_CHANGELOG)
+
+	expected := `# Changelog
+
+## 1.4.0 (2026-10-18)
+
+* New
+
+## 1.3.0 (2026-09-01)
+
+* One
+`
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, expected, Update(result, section, CONVENTIONAL_CHANGELOG))
+}
+
+func TestUpdate_should_render_conventional_changelog_header_with_compare_link(t *testing.T) {
+	result := Update("# Changelog\n", Section{
+		Version:    mustParseVersion(t, "1.4.0"),
+		Date:       date,
+		CompareURL: "https://example.com/compare/v1.3.0...v1.4.0",
+		Content:    "* Feature",
+	}, CONVENTIONAL_CHANGELOG)
+
+	assert.Equal(t, "# Changelog\n\n## [1.4.0](https://example.com/compare/v1.3.0...v1.4.0) (2026-10-18)\n\n* Feature\n", result)
+}
+
+func TestUpdate_should_preserve_crlf_line_endings(t *testing.T) {
+	existing := "# Changelog\r\n\r\n## [1.0.0] - 2026-10-18\r\n\r\n* Fix\r\n\r\n[1.0.0]: https://example.com/releases/v1.0.0\r\n"
+
+	result := Update(existing, Section{
+		Version:    mustParseVersion(t, "1.1.0"),
+		Date:       date,
+		CompareURL: "https://example.com/compare/v1.0.0...v1.1.0",
+		Content:    "### Features\r\n\r\n* Feature\n",
+	}, KEEP_A_CHANGELOG)
+
+	assert.Equal(t, "# Changelog\r\n\r\n## [1.1.0] - 2026-10-18\r\n\r\n### Features\r\n\r\n* Feature\r\n\r\n"+
+		"## [1.0.0] - 2026-10-18\r\n\r\n* Fix\r\n\r\n"+
+		"[1.1.0]: https://example.com/compare/v1.0.0...v1.1.0\r\n[1.0.0]: https://example.com/releases/v1.0.0\r\n", result)
+}
+
+func TestParse_should_ignore_headers_in_code_blocks(t *testing.T) {
+	changelog := Parse("# Changelog\n\n## 1.0.0\n\n```\n## 0.1.0\n```\n")
+
+	assert.Len(t, changelog.Sections, 1)
+	assert.Equal(t, "1.0.0", changelog.Sections[0].Label)
+}
+
+func TestRender_should_render_all_sections_in_order(t *testing.T) {
+	result := Render([]Section{
+		{Content: "### Features\n\n* New"},
+		{Version: mustParseVersion(t, "1.1.0"), Date: date, Content: "### Bug Fixes\n\n* Fix"},
+		{Version: mustParseVersion(t, "1.0.0"), Date: date},
+	}, CONVENTIONAL_CHANGELOG)
+
+	assert.Equal(t, `# Changelog
+
+## Unreleased
+
+### Features
+
+* New
+
+## 1.1.0 (2026-10-18)
+
+### Bug Fixes
+
+* Fix
+
+## 1.0.0 (2026-10-18)
+`, result)
+}
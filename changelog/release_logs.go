@@ -0,0 +1,35 @@
+package changelog
+
+import (
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/version_log"
+)
+
+// Creates a section for each release log. The content is rendered from the release notes of the commits.
+// The unreleased section has no date. The sections contain no links if l is nil.
+func SectionsFromReleaseLogs(logs []*version_log.ReleaseLog, renderer release_notes.Renderer, options release_notes.Options, l *links.Links) ([]Section, error) {
+
+	sections := make([]Section, 0, len(logs))
+
+	for _, log := range logs {
+
+		notes := release_notes.FromReleaseLog(log, options)
+		notes.AddLinks(l, log.PreviousTagName)
+
+		content, err := renderer.Render(notes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, Section{
+			Version:    log.Version,
+			Date:       log.Date,
+			CompareURL: notes.CompareURL,
+			Content:    content,
+		})
+	}
+
+	return sections, nil
+}
@@ -0,0 +1,122 @@
+package bump
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/bump"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/diff_utils"
+	"github.com/psanetra/git-semver/latest"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var stable bool
+var majorVersionFilter int
+var preReleaseTag string
+var appendPreReleaseCounter bool
+var releaseCommitMessage string
+var check bool
+var includePreReleases bool
+var dryRun bool
+
+var Command = cobra.Command{
+	Use:   "bump",
+	Short: "updates the version in project files",
+	Long: `This command calculates the next semantic version like the "next" command and writes it into the project files (e.g. package.json, pom.xml, Chart.yaml, Cargo.toml, pyproject.toml or VERSION). It prints the paths of all changed files.
+
+The files are configured in the "bump" section of the configuration file. Supported files in the root of the repository are updated if no files are configured.
+
+With --check no files are changed. Instead the command fails if a file does not contain the latest version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		var version *semver.Version
+
+		if check {
+			version, err = latest.Latest(latest.LatestOptions{
+				Workdir:            common_opts.Workdir,
+				IncludePreReleases: includePreReleases,
+				MajorVersionFilter: majorVersionFilter,
+			})
+		} else {
+			version, err = next.Next(next.NextOptions{
+				Workdir:            common_opts.Workdir,
+				Stable:             stable,
+				MajorVersionFilter: majorVersionFilter,
+				PreReleaseOptions: semver.PreReleaseOptions{
+					Label:         preReleaseTag,
+					AppendCounter: appendPreReleaseCounter,
+				},
+				ReleaseCommitMessage: releaseCommitMessage,
+				Parser:               project.Parser,
+				Ignore:               project.Ignore,
+				Overrides:            project.Overrides,
+				SecurityPatch:        project.Config.Security.Patch,
+			})
+		}
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		results, err := bump.Bump(bump.BumpOptions{
+			Workdir: common_opts.Workdir,
+			Files:   project.Config.Bump.Files,
+			Version: version.ToString(),
+			DryRun:  dryRun || check,
+		})
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if check {
+			outdated := false
+
+			for _, result := range results {
+				if result.Changed() {
+					outdated = true
+					fmt.Fprintf(os.Stderr, "%s: found %s (expected %s)\n", result.Path, strings.Join(result.PreviousVersions, ", "), version.ToString())
+				}
+			}
+
+			if outdated {
+				logger.Logger.Fatalln("Some files do not contain the version", version.ToString())
+			}
+
+			return
+		}
+
+		for _, result := range results {
+			if !result.Changed() {
+				continue
+			}
+
+			if dryRun {
+				fmt.Print(diff_utils.Unified(result.Path, result.PreviousContent, result.Content))
+			} else {
+				fmt.Println(result.Path)
+			}
+		}
+	},
+}
+
+func init() {
+	Command.Flags().BoolVar(&stable, "stable", true, "Specifies if this project is considered stable. Setting this to false will cause the major version to be 0. This command will fail if there is already a major version greater than 0.")
+	Command.Flags().IntVar(&majorVersionFilter, "major-version", -1, "Only consider tags with this specific major version.")
+	Command.Flags().StringVar(&preReleaseTag, "pre-release-tag", "", "Specifies a pre-release tag which should be appended to the next version.")
+	Command.Flags().BoolVar(&appendPreReleaseCounter, "pre-release-counter", false, "Specifies if there should be a counter appended to the pre-release tag. It will increase automatically depending on previous pre-releases for the same version.")
+	Command.Flags().StringVar(&releaseCommitMessage, "release-commit-message", next.DEFAULT_RELEASE_COMMIT_MESSAGE, "Template of release commit messages created by the release command. Matching commits are skipped.")
+	Command.Flags().BoolVar(&check, "check", false, "Only verify that all files contain the latest version.")
+	Command.Flags().BoolVar(&includePreReleases, "include-pre-releases", false, "Also consider pre-releases as the latest version for --check.")
+	Command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the planned changes without changing anything.")
+}
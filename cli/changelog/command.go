@@ -0,0 +1,174 @@
+package changelog
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/changelog"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/contributors"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/version_log"
+	"github.com/spf13/cobra"
+)
+
+var constraint string
+var since string
+var separatePreReleases bool
+var style string
+var templateFile string
+var format string
+var noLinks bool
+var groupByScope string
+var contributorsSection bool
+
+var Command = cobra.Command{
+	Use:   "changelog",
+	Short: "prints the changelog of all versions",
+	Long: `This command prints a complete changelog with a section for each version tag and an "Unreleased" section for the commits since the latest version. Each section contains the version, the date of the tag and the release notes of the commits since the preceding version.
+
+The commits of pre-releases are contained in the section of the following release unless --separate-pre-releases is set. Pre-releases without a following release always get their own section.
+
+The changelog is formatted according to --format. The content of each section can also be rendered with a text/template file passed via --template.
+
+Commits, referenced issues and the changes between versions are linked if the hosting provider can be detected from the remote url of "origin" or if links are configured in the "links" section of the configuration file.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		changelogStyle, err := changelog.ParseStyle(style)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		outputFormat, err := release_notes.ParseFormat(format)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if templateFile != "" && outputFormat != release_notes.MARKDOWN {
+			logger.Logger.Fatalln("Flags --template and --format are mutual exclusive")
+		}
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		options := version_log.ReleaseLogsOptions{
+			Workdir:             common_opts.Workdir,
+			SeparatePreReleases: separatePreReleases,
+			Ignore:              project.Ignore,
+		}
+
+		if constraint != "" {
+			options.Constraint, err = semver.ParseConstraint(constraint)
+
+			if err != nil {
+				logger.Logger.Fatalln(err)
+			}
+		}
+
+		if since != "" {
+			options.Since, err = semver.ParseVersion(since)
+
+			if err != nil {
+				logger.Logger.Fatalln("Could not parse version:", err)
+			}
+		}
+
+		logs, err := version_log.ReleaseLogs(options)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		renderer, err := release_notes.LoadRenderer(outputFormat, templateFile)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if noLinks {
+			project.Config.Links.Disabled = true
+		}
+
+		repoLinks, err := links.Resolve(common_opts.Workdir, project.Config.Links)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		parser, err := references.NewParser(project.Config.References)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if groupByScope != "" {
+			project.Config.Changelog.Scopes.Grouping = groupByScope
+		}
+
+		if contributorsSection {
+			project.Config.Changelog.Contributors.Section = true
+		}
+
+		collector, err := contributors.NewCollector(common_opts.Workdir, project.Config.Changelog.Contributors)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		layout, err := release_notes.NewLayout(project.Config.Changelog)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		releaseNotesOptions := release_notes.Options{References: parser, Layout: layout, Contributors: collector, Parser: project.Parser, Overrides: project.Overrides}
+
+		// custom templates, which define a "document" template, render the whole changelog
+		if outputFormat != release_notes.MARKDOWN || release_notes.DefinesDocument(renderer) {
+			var notes []*release_notes.ReleaseNotes
+
+			for _, log := range logs {
+				n := release_notes.FromReleaseLog(log, releaseNotesOptions)
+				n.AddLinks(repoLinks, log.PreviousTagName)
+				notes = append(notes, n)
+			}
+
+			output, err := renderer.RenderAll(notes)
+
+			if err != nil {
+				logger.Logger.Fatalln(err)
+			}
+
+			fmt.Print(output)
+			return
+		}
+
+		sections, err := changelog.SectionsFromReleaseLogs(logs, renderer, releaseNotesOptions, repoLinks)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		fmt.Print(changelog.Render(sections, changelogStyle))
+	},
+}
+
+func init() {
+	Command.Flags().StringVar(&constraint, "constraint", "", "Only print versions matching this constraint (e.g. \">=1.2.0 <2.0.0\" or \"^1.2 || ^2\").")
+	Command.Flags().StringVar(&since, "since", "", "Only print this and all greater versions.")
+	Command.Flags().BoolVar(&separatePreReleases, "separate-pre-releases", false, "Print a separate section for each pre-release instead of including their commits in the following release.")
+	Command.Flags().StringVar(&templateFile, "template", "", "Template file (text/template) for the content of each version section. Defaults to the built-in markdown template.")
+	Command.Flags().StringVar(&style, "style", string(changelog.KEEP_A_CHANGELOG), "Style of the version headers of the markdown format: keepachangelog | conventional")
+	Command.Flags().StringVar(&format, "format", string(release_notes.MARKDOWN), "Output format: markdown | json | html | asciidoc | rst | text")
+	Command.Flags().BoolVar(&noLinks, "no-links", false, "Do not link commits, issues and versions (e.g. for offline formats).")
+	Command.Flags().StringVar(&groupByScope, "group-by-scope", "", "Group the entries of each section by scope in markdown: headings | bold. Overrides the grouping of the configuration file.")
+	Command.Flags().BoolVar(&contributorsSection, "contributors", false, "Render a section with the contributors in markdown. Bots are excluded.")
+}
@@ -1,3 +1,9 @@
 package common_opts
 
 var Workdir = ""
+
+// ConfigFile overrides the path of the project configuration file. See config.DEFAULT_FILE.
+var ConfigFile = ""
+
+// ParserMode overrides the mode of the commit parser of the project configuration file if it is not empty
+var ParserMode = ""
@@ -0,0 +1,56 @@
+package common_opts
+
+import (
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/overrides"
+)
+
+// Project contains the configuration of the project and the settings, which classify its commits
+type Project struct {
+	Config    *config.Config
+	Parser    commit_parser.Parser
+	Ignore    *ignore.Rules
+	Overrides *overrides.Overrides
+}
+
+// Loads the configuration of the project in Workdir and creates the commit parser, the ignore rules and the
+// overrides. ParserMode overrides the configured parser mode.
+func LoadProject() (*Project, error) {
+
+	cfg, err := config.Load(Workdir, ConfigFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ParserMode != "" {
+		cfg.Parser.Mode = ParserMode
+	}
+
+	commitParser, err := commit_parser.New(cfg.Parser)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreRules, err := ignore.NewRules(cfg.Ignore)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commitOverrides, err := overrides.Load(Workdir, cfg.Overrides)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Project{
+		Config:    cfg,
+		Parser:    commitParser,
+		Ignore:    ignoreRules,
+		Overrides: commitOverrides,
+	}, nil
+}
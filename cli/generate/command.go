@@ -0,0 +1,132 @@
+package generate
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/diff_utils"
+	"github.com/psanetra/git-semver/generate"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var stable bool
+var majorVersionFilter int
+var preReleaseTag string
+var appendPreReleaseCounter bool
+var releaseCommitMessage string
+var prefix string
+var buildMetadata string
+var templateName string
+var output string
+var useLatest bool
+var includePreReleases bool
+var check bool
+var dryRun bool
+
+var Command = cobra.Command{
+	Use:   "generate",
+	Short: "renders version source files from templates",
+	Long: `This command renders templates with the next semantic version (calculated like the "next" command) or with the latest semantic version (--latest) and writes the results into files. It prints the paths of all changed files.
+
+The files are configured in the "generate" section of the configuration file or with --template and --output. Built-in templates are go, c, properties and typescript. Other templates are read from files and rendered with Go's text/template package.
+
+With --check no files are changed. Instead the command fails if a file is not up to date with the latest version. Lines derived from the commit (e.g. .Commit) are not compared.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		files := project.Config.Generate.Files
+
+		if templateName != "" || output != "" {
+			if templateName == "" || output == "" {
+				logger.Logger.Fatalln("Flags --template and --output have to be used together")
+			}
+
+			files = []config.GenerateFile{{Path: output, Template: templateName}}
+		}
+
+		if len(files) == 0 {
+			logger.Logger.Fatalln("No files configured")
+		}
+
+		_, results, err := generate.Generate(generate.GenerateOptions{
+			NextOptions: next.NextOptions{
+				Workdir:            common_opts.Workdir,
+				Stable:             stable,
+				MajorVersionFilter: majorVersionFilter,
+				PreReleaseOptions: semver.PreReleaseOptions{
+					Label:         preReleaseTag,
+					AppendCounter: appendPreReleaseCounter,
+				},
+				ReleaseCommitMessage: releaseCommitMessage,
+				Parser:               project.Parser,
+				Ignore:               project.Ignore,
+				Overrides:            project.Overrides,
+				SecurityPatch:        project.Config.Security.Patch,
+			},
+			Latest:             useLatest || check,
+			IncludePreReleases: includePreReleases,
+			Prefix:             prefix,
+			BuildMetadata:      buildMetadata,
+			Files:              files,
+			DryRun:             dryRun || check,
+		})
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if check {
+			outdated := false
+
+			for _, result := range results {
+				if result.VersionChanged() {
+					outdated = true
+					fmt.Fprintln(os.Stderr, result.Path, "is not up to date")
+				}
+			}
+
+			if outdated {
+				logger.Logger.Fatalln("Some generated files are not up to date")
+			}
+
+			return
+		}
+
+		for _, result := range results {
+			if !result.Changed() {
+				continue
+			}
+
+			if dryRun {
+				fmt.Print(diff_utils.Unified(result.Path, result.PreviousContent, result.Content))
+			} else {
+				fmt.Println(result.Path)
+			}
+		}
+	},
+}
+
+func init() {
+	Command.Flags().BoolVar(&stable, "stable", true, "Specifies if this project is considered stable. Setting this to false will cause the major version to be 0. This command will fail if there is already a major version greater than 0.")
+	Command.Flags().IntVar(&majorVersionFilter, "major-version", -1, "Only consider tags with this specific major version.")
+	Command.Flags().StringVar(&preReleaseTag, "pre-release-tag", "", "Specifies a pre-release tag which should be appended to the next version.")
+	Command.Flags().BoolVar(&appendPreReleaseCounter, "pre-release-counter", false, "Specifies if there should be a counter appended to the pre-release tag. It will increase automatically depending on previous pre-releases for the same version.")
+	Command.Flags().StringVar(&releaseCommitMessage, "release-commit-message", next.DEFAULT_RELEASE_COMMIT_MESSAGE, "Template of release commit messages created by the release command. Matching commits are skipped.")
+	Command.Flags().StringVar(&prefix, "prefix", "v", "Prefix of the tag name of the next version.")
+	Command.Flags().StringVar(&buildMetadata, "build-metadata", "", "Build metadata, which is appended to the version (e.g. build.42).")
+	Command.Flags().StringVar(&templateName, "template", "", "Built-in template (go | c | properties | typescript) or template file. Overrides the configured files.")
+	Command.Flags().StringVar(&output, "output", "", "Path of the generated file. Overrides the configured files.")
+	Command.Flags().BoolVar(&useLatest, "latest", false, "Use the latest version and the commit of its tag instead of the next version and HEAD.")
+	Command.Flags().BoolVar(&includePreReleases, "include-pre-releases", false, "Also consider pre-releases as the latest version for --latest and --check.")
+	Command.Flags().BoolVar(&check, "check", false, "Only verify that all files are up to date with the latest version. Lines derived from the commit are ignored.")
+	Command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the planned changes without changing anything.")
+}
@@ -3,9 +3,15 @@ package log
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/contributors"
 	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
 	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/pretty"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/release_notes"
 	"github.com/psanetra/git-semver/semver"
 	"github.com/psanetra/git-semver/version_log"
 	"github.com/spf13/cobra"
@@ -14,65 +20,238 @@ import (
 var excludePreReleases bool
 var outputAsConventionalCommits bool
 var markdownChangelog bool
+var templateFile string
+var format string
+var noLinks bool
+var groupByScope string
+var contributorsSection bool
+var prettyFormat string
+var filterTypes []string
+var filterScopes []string
+var breakingOnly bool
+var filterAuthor string
+var filterPaths []string
+var grep string
+
+// conventionalCommit is printed by --conventional-commits
+type conventionalCommit struct {
+	*conventional_commits.ConventionalCommitMessage
+	References []*references.Reference `json:"references,omitempty"`
+}
 
 var Command = cobra.Command{
-	Use:   "log [<version>]",
+	Use:   "log [<version> | <from>..<to> | <from>...<to>]",
 	Short: "prints the git log for the specified version",
-	Long:  "This command prints all commits, which were contained in a specified version or all commits since the latest version if no version is specified.",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `This command prints all commits, which were contained in a specified version or all commits since the latest version if no version is specified.
+
+A range "<from>..<to>" prints all commits reachable from <to>, but not from <from>. A range "<from>...<to>" prints all commits reachable from either <from> or <to>, but not from both. The endpoints may be versions, tags, branches or commit hashes. An omitted endpoint defaults to HEAD.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 
 		var version *semver.Version
+		var commitRange *version_log.Range
+		var commits []*object.Commit
 		var err error
 
 		if len(args) > 0 {
-			version, err = semver.ParseVersion(args[0])
-			if err != nil {
-				logger.Logger.Fatalln("Could not parse version:", err)
+			var isRange bool
+			commitRange, isRange = version_log.ParseRange(args[0])
+
+			if !isRange {
+				version, err = semver.ParseVersion(args[0])
+				if err != nil {
+					logger.Logger.Fatalln("Could not parse version:", err)
+				}
+			}
+		}
+
+		if markdownChangelog {
+			if format != "" && format != string(release_notes.MARKDOWN) {
+				logger.Logger.Fatalln("Flags --markdown and --format are mutual exclusive")
 			}
+
+			format = string(release_notes.MARKDOWN)
+		}
+
+		if templateFile != "" && format != "" && format != string(release_notes.MARKDOWN) {
+			logger.Logger.Fatalln("Flags --template and --format are mutual exclusive")
+		}
+
+		renderReleaseNotes := format != "" || templateFile != ""
+
+		if outputAsConventionalCommits && renderReleaseNotes {
+			logger.Logger.Fatalln("Flag --conventional-commits is mutual exclusive with --format, --markdown and --template")
+		}
+
+		if prettyFormat != "" && (outputAsConventionalCommits || renderReleaseNotes) {
+			logger.Logger.Fatalln("Flag --pretty is mutual exclusive with --conventional-commits, --format, --markdown and --template")
 		}
 
-		commits, err := version_log.VersionLog(version_log.VersionLogOptions{
-			Workdir:                  common_opts.Workdir,
-			Version:                  version,
-			ExcludePreReleaseCommits: excludePreReleases,
-		})
+		project, err := common_opts.LoadProject()
 
 		if err != nil {
 			logger.Logger.Fatalln(err)
 		}
 
-		if !outputAsConventionalCommits && !markdownChangelog {
-			for _, commit := range commits {
-				fmt.Print(commit)
+		filter := &version_log.Filter{
+			Scopes:       filterScopes,
+			BreakingOnly: breakingOnly,
+			Author:       filterAuthor,
+			Paths:        filterPaths,
+			Grep:         grep,
+			Parser:       project.Parser,
+			Overrides:    project.Overrides,
+		}
+
+		for _, changeType := range filterTypes {
+			filter.Types = append(filter.Types, conventional_commits.ChangeType(changeType))
+		}
+
+		if commitRange != nil {
+			if excludePreReleases {
+				logger.Logger.Fatalln("Flag --exclude-pre-releases can not be used with a range")
 			}
-		} else if outputAsConventionalCommits && markdownChangelog {
-			logger.Logger.Fatalln("Flags --conventional-commits and --markdown-changelog are mutual exclusive")
+
+			commits, err = version_log.RangeLog(version_log.RangeLogOptions{
+				Workdir: common_opts.Workdir,
+				Range:   commitRange,
+				Filter:  filter,
+				Ignore:  project.Ignore,
+			})
 		} else {
+			commits, err = version_log.VersionLog(version_log.VersionLogOptions{
+				Workdir:                  common_opts.Workdir,
+				Version:                  version,
+				ExcludePreReleaseCommits: excludePreReleases,
+				Filter:                   filter,
+				Ignore:                   project.Ignore,
+			})
+		}
 
-			var conventionalCommits []*conventional_commits.ConventionalCommitMessage
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
 
-			for _, commit := range commits {
-				conventionalCommit, err := conventional_commits.ParseCommitMessage(commit.Message)
+		if noLinks {
+			project.Config.Links.Disabled = true
+		}
+
+		repoLinks, err := links.Resolve(common_opts.Workdir, project.Config.Links)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		parser, err := references.NewParser(project.Config.References)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if groupByScope != "" {
+			project.Config.Changelog.Scopes.Grouping = groupByScope
+		}
+
+		if contributorsSection {
+			project.Config.Changelog.Contributors.Section = true
+		}
+
+		collector, err := contributors.NewCollector(common_opts.Workdir, project.Config.Changelog.Contributors)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		layout, err := release_notes.NewLayout(project.Config.Changelog)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		releaseNotesOptions := release_notes.Options{References: parser, Layout: layout, Contributors: collector, Parser: project.Parser, Overrides: project.Overrides}
+
+		if renderReleaseNotes {
+			outputFormat := release_notes.MARKDOWN
+
+			if format != "" {
+				outputFormat, err = release_notes.ParseFormat(format)
 
 				if err != nil {
+					logger.Logger.Fatalln(err)
+				}
+			}
+
+			renderer, err := release_notes.LoadRenderer(outputFormat, templateFile)
+
+			if err != nil {
+				logger.Logger.Fatalln(err)
+			}
+
+			notes := release_notes.New(commits, releaseNotesOptions)
+
+			if version != nil {
+				notes.Version = version.ToString()
+			}
+
+			notes.AddLinks(repoLinks, "")
+
+			output, err := renderer.Render(notes)
+
+			if err != nil {
+				logger.Logger.Fatalln(err)
+			}
+
+			fmt.Print(output)
+		} else if outputAsConventionalCommits {
+
+			var conventionalCommits []*conventionalCommit
+
+			for _, commit := range commits {
+				message, excluded, err := project.Overrides.Classify(commit, project.Parser)
+
+				if excluded {
+					logger.Logger.Debugln("Excluding commit", commit.Hash.String(), "by override")
+					continue
+				} else if err != nil {
 					logger.Logger.Debugln(err)
 					continue
 				}
 
-				conventionalCommits = append(conventionalCommits, conventionalCommit)
+				refs := parser.Parse(message)
+
+				if repoLinks != nil {
+					for _, reference := range refs {
+						reference.AddLink(repoLinks)
+					}
+				}
+
+				conventionalCommits = append(conventionalCommits, &conventionalCommit{
+					ConventionalCommitMessage: message,
+					References:                refs,
+				})
 			}
 
-			if markdownChangelog {
-				fmt.Print(conventional_commits.ToMarkdown(conventionalCommits))
-			} else if outputAsConventionalCommits {
-				jsonResult, err := json.MarshalIndent(conventionalCommits, "", "  ")
+			jsonResult, err := json.MarshalIndent(conventionalCommits, "", "  ")
 
-				if err != nil {
-					logger.Logger.Fatalln("Could not marshal json:", err)
-				}
+			if err != nil {
+				logger.Logger.Fatalln("Could not marshal json:", err)
+			}
 
-				fmt.Println(string(jsonResult))
+			fmt.Println(string(jsonResult))
+		} else if prettyFormat != "" {
+			p, err := pretty.Parse(prettyFormat)
+
+			if err != nil {
+				logger.Logger.Fatalln(err)
+			}
+
+			p.Parser = project.Parser
+			p.Overrides = project.Overrides
+
+			fmt.Print(p.Format(commits))
+		} else {
+			for _, commit := range commits {
+				fmt.Print(commit)
 			}
 		}
 	},
@@ -81,5 +260,17 @@ var Command = cobra.Command{
 func init() {
 	Command.Flags().BoolVar(&excludePreReleases, "exclude-pre-releases", false, "Specifies if the log should exclude pre-release commits from the log.")
 	Command.Flags().BoolVar(&outputAsConventionalCommits, "conventional-commits", false, "Print only conventional commits, formatted as JSON. Non-parsable commits are omitted.")
-	Command.Flags().BoolVar(&markdownChangelog, "markdown", false, "Print changelog, formatted as markdown.")
+	Command.Flags().BoolVar(&markdownChangelog, "markdown", false, "Print changelog, formatted as markdown. Alias for --format markdown.")
+	Command.Flags().StringVar(&format, "format", "", "Print changelog in this format: markdown | json | html | asciidoc | rst | text")
+	Command.Flags().StringVar(&templateFile, "template", "", "Print changelog, rendered with this template file (text/template).")
+	Command.Flags().StringVar(&prettyFormat, "pretty", "", "Print commits in this format: oneline | short | full | format:<spec> (e.g. \"format:%h %(type) %(description)\")")
+	Command.Flags().StringSliceVar(&filterTypes, "type", nil, "Print only conventional commits of these types (e.g. feat,fix).")
+	Command.Flags().StringSliceVar(&filterScopes, "scope", nil, "Print only conventional commits with a scope matching one of these glob patterns (e.g. \"api*\").")
+	Command.Flags().BoolVar(&breakingOnly, "breaking-only", false, "Print only conventional commits with breaking changes.")
+	Command.Flags().StringVar(&filterAuthor, "author", "", "Print only commits with an author (\"Name <email>\") matching this regex.")
+	Command.Flags().StringSliceVar(&filterPaths, "path", nil, "Print only commits changing files in these directories or matching these glob patterns, relative to the root of the repository.")
+	Command.Flags().StringVar(&grep, "grep", "", "Print only commits with a description matching this regex.")
+	Command.Flags().BoolVar(&noLinks, "no-links", false, "Do not link commits and issues (e.g. for offline formats).")
+	Command.Flags().StringVar(&groupByScope, "group-by-scope", "", "Group the entries of each section by scope in markdown: headings | bold. Overrides the grouping of the configuration file.")
+	Command.Flags().BoolVar(&contributorsSection, "contributors", false, "Render a section with the contributors in markdown. Bots are excluded.")
 }
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"github.com/psanetra/git-semver/cli/bump"
+	"github.com/psanetra/git-semver/cli/changelog"
 	"github.com/psanetra/git-semver/cli/common_opts"
 	"github.com/psanetra/git-semver/cli/compare"
+	"github.com/psanetra/git-semver/cli/generate"
 	"github.com/psanetra/git-semver/cli/latest"
 	"github.com/psanetra/git-semver/cli/log"
 	"github.com/psanetra/git-semver/cli/next"
+	"github.com/psanetra/git-semver/cli/release"
+	"github.com/psanetra/git-semver/cli/tag"
+	"github.com/psanetra/git-semver/cli/upgrade_guide"
+	"github.com/psanetra/git-semver/config"
 	"github.com/psanetra/git-semver/logger"
 	"github.com/spf13/cobra"
 )
@@ -31,6 +38,12 @@ func main() {
 	rootCmd.AddCommand(&next.Command)
 	rootCmd.AddCommand(&log.Command)
 	rootCmd.AddCommand(&compare.Command)
+	rootCmd.AddCommand(&tag.Command)
+	rootCmd.AddCommand(&release.Command)
+	rootCmd.AddCommand(&bump.Command)
+	rootCmd.AddCommand(&generate.Command)
+	rootCmd.AddCommand(&changelog.Command)
+	rootCmd.AddCommand(&upgrade_guide.Command)
 	err := rootCmd.Execute()
 
 	if err != nil {
@@ -41,6 +54,8 @@ func main() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&common_opts.Workdir, "workdir", "w", ".", "Working directory to use")
+	rootCmd.PersistentFlags().StringVar(&common_opts.ConfigFile, "config", "", "Configuration file. Defaults to "+config.DEFAULT_FILE+" in the root of the repository if it exists.")
+	rootCmd.PersistentFlags().StringVar(&common_opts.ParserMode, "parser-mode", "", "Mode of the conventional commits parser: lenient | strict. Overrides the mode of the configuration file. Defaults to lenient.")
 	rootCmd.PersistentFlags().String("log-level", logger.DEFAULT_LOG_LEVEL.String(), "panic | fatal | error | warn | info | debug | trace")
 }
 
@@ -7,12 +7,19 @@ import (
 	"github.com/psanetra/git-semver/next"
 	"github.com/psanetra/git-semver/semver"
 	"github.com/spf13/cobra"
+	"io"
+	"os"
 )
 
 var stable bool
 var majorVersionFilter int
 var preReleaseTag string
 var appendPreReleaseCounter bool
+var releaseCommitMessage string
+var explain bool
+var securityPatch bool
+var checkSecurity bool
+var securityMaxUnreleasedCommits int
 
 var Command = cobra.Command{
 	Use:   "next",
@@ -20,15 +27,43 @@ var Command = cobra.Command{
 	Long:  `This command can be used to calculate the next semantic version based on the history of the current branch. It fails if the git tag of the latest semantic version is not reachable on the current branch or if the tagged commit is not reachable because the repository is shallow.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		nextVersion, err := next.Next(next.NextOptions{
-			Workdir: common_opts.Workdir,
-			Stable:  stable,
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if securityPatch {
+			project.Config.Security.Patch = true
+		}
+
+		if securityMaxUnreleasedCommits >= 0 {
+			project.Config.Security.MaxUnreleasedCommits = securityMaxUnreleasedCommits
+		}
+
+		nextOptions := next.NextOptions{
+			Workdir:            common_opts.Workdir,
+			Stable:             stable,
 			MajorVersionFilter: majorVersionFilter,
 			PreReleaseOptions: semver.PreReleaseOptions{
 				Label:         preReleaseTag,
 				AppendCounter: appendPreReleaseCounter,
 			},
-		})
+			ReleaseCommitMessage: releaseCommitMessage,
+			Explain:              explainWriter(),
+			Parser:               project.Parser,
+			Ignore:               project.Ignore,
+			Overrides:            project.Overrides,
+			SecurityPatch:        project.Config.Security.Patch,
+		}
+
+		if checkSecurity {
+			if err = next.AssertSecurityFixesReleased(nextOptions, project.Config.Security.MaxUnreleasedCommits); err != nil {
+				logger.Logger.Fatalln(err)
+			}
+		}
+
+		nextVersion, err := next.Next(nextOptions)
 
 		if err != nil {
 			logger.Logger.Fatalln(err)
@@ -39,9 +74,22 @@ var Command = cobra.Command{
 	},
 }
 
+func explainWriter() io.Writer {
+	if explain {
+		return os.Stderr
+	}
+
+	return nil
+}
+
 func init() {
 	Command.Flags().BoolVar(&stable, "stable", true, "Specifies if this project is considered stable. Setting this to false will cause the major version to be 0. This command will fail if there is already a major version greater than 0.")
 	Command.Flags().IntVar(&majorVersionFilter, "major-version", -1, "Only consider tags with this specific major version.")
 	Command.Flags().StringVar(&preReleaseTag, "pre-release-tag", "", "Specifies a pre-release tag which should be appended to the next version.")
 	Command.Flags().BoolVar(&appendPreReleaseCounter, "pre-release-counter", false, "Specifies if there should be a counter appended to the pre-release tag. It will increase automatically depending on previous pre-releases for the same version.")
+	Command.Flags().BoolVar(&explain, "explain", false, "Print to stderr how each commit since the latest version affects the next version, including why commits are no conventional commits.")
+	Command.Flags().StringVar(&releaseCommitMessage, "release-commit-message", next.DEFAULT_RELEASE_COMMIT_MESSAGE, "Template of release commit messages created by the release command. Matching commits are skipped.")
+	Command.Flags().BoolVar(&securityPatch, "security-patch", false, "Enforce at least a patch release if there are security fixes (commits of the type security or with CVE or GHSA references in their footers). Overrides the security.patch option of the configuration file.")
+	Command.Flags().BoolVar(&checkSecurity, "check-security", false, "Fail if a security fix is followed by more commits since the latest version than the security.max_unreleased_commits option of the configuration file allows.")
+	Command.Flags().IntVar(&securityMaxUnreleasedCommits, "security-max-unreleased-commits", -1, "Maximum number of commits following an unreleased security fix for --check-security. 0 disables the check. Overrides the security.max_unreleased_commits option of the configuration file.")
 }
@@ -0,0 +1,170 @@
+package release
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/changelog"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/contributors"
+	"github.com/psanetra/git-semver/diff_utils"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/release"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/spf13/cobra"
+)
+
+var stable bool
+var majorVersionFilter int
+var preReleaseTag string
+var appendPreReleaseCounter bool
+var prefix string
+var changelogFile string
+var changelogStyle string
+var templateFile string
+var commitMessage string
+var dryRun bool
+var noLinks bool
+var groupByScope string
+var contributorsSection bool
+var securityPatch bool
+var securityMaxUnreleasedCommits int
+
+var Command = cobra.Command{
+	Use:   "release",
+	Short: "creates a release commit with an updated changelog and tags it",
+	Long: `This command calculates the next semantic version like the "next" command, inserts a section for it into the changelog file and commits the changelog file. The section of the same version is replaced if it already exists. The release commit is tagged with the new version. Release commits are skipped by future "next" calculations.
+
+The files configured in the "bump" section of the configuration file are updated with the new version and become part of the release commit.
+
+Commits, referenced issues and the changes since the previous version are linked in the changelog if the hosting provider can be detected from the remote url of "origin" or if links are configured in the "links" section of the configuration file.
+
+The --commit-message template may contain the placeholder {version}.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		style, err := changelog.ParseStyle(changelogStyle)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if noLinks {
+			project.Config.Links.Disabled = true
+		}
+
+		repoLinks, err := links.Resolve(common_opts.Workdir, project.Config.Links)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		parser, err := references.NewParser(project.Config.References)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if securityPatch {
+			project.Config.Security.Patch = true
+		}
+
+		if securityMaxUnreleasedCommits >= 0 {
+			project.Config.Security.MaxUnreleasedCommits = securityMaxUnreleasedCommits
+		}
+
+		if groupByScope != "" {
+			project.Config.Changelog.Scopes.Grouping = groupByScope
+		}
+
+		if contributorsSection {
+			project.Config.Changelog.Contributors.Section = true
+		}
+
+		collector, err := contributors.NewCollector(common_opts.Workdir, project.Config.Changelog.Contributors)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		layout, err := release_notes.NewLayout(project.Config.Changelog)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		releaseNotesOptions := release_notes.Options{References: parser, Layout: layout, Contributors: collector, Parser: project.Parser, Overrides: project.Overrides}
+
+		result, err := release.Release(release.ReleaseOptions{
+			NextOptions: next.NextOptions{
+				Workdir:            common_opts.Workdir,
+				Stable:             stable,
+				MajorVersionFilter: majorVersionFilter,
+				PreReleaseOptions: semver.PreReleaseOptions{
+					Label:         preReleaseTag,
+					AppendCounter: appendPreReleaseCounter,
+				},
+				ReleaseCommitMessage: commitMessage,
+				Parser:               project.Parser,
+				Ignore:               project.Ignore,
+				Overrides:            project.Overrides,
+				SecurityPatch:        project.Config.Security.Patch,
+			},
+			Prefix:                       prefix,
+			ChangelogFile:                changelogFile,
+			ChangelogStyle:               style,
+			TemplateFile:                 templateFile,
+			ReleaseNotes:                 releaseNotesOptions,
+			Links:                        repoLinks,
+			BumpFiles:                    project.Config.Bump.Files,
+			DryRun:                       dryRun,
+			SecurityMaxUnreleasedCommits: project.Config.Security.MaxUnreleasedCommits,
+		})
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if !dryRun {
+			fmt.Print(result.Version.ToString())
+			return
+		}
+
+		fmt.Println("Version:", result.Version.ToString())
+		fmt.Println("Tag:", result.TagName)
+		fmt.Println("Commit message:", result.CommitMessage)
+		fmt.Println()
+		fmt.Print(diff_utils.Unified(result.ChangelogFile, result.PreviousChangelog, result.Changelog))
+
+		for _, bumpResult := range result.BumpResults {
+			if bumpResult.Changed() {
+				fmt.Print(diff_utils.Unified(bumpResult.Path, bumpResult.PreviousContent, bumpResult.Content))
+			}
+		}
+	},
+}
+
+func init() {
+	Command.Flags().BoolVar(&stable, "stable", true, "Specifies if this project is considered stable. Setting this to false will cause the major version to be 0. This command will fail if there is already a major version greater than 0.")
+	Command.Flags().IntVar(&majorVersionFilter, "major-version", -1, "Only consider tags with this specific major version.")
+	Command.Flags().StringVar(&preReleaseTag, "pre-release-tag", "", "Specifies a pre-release tag which should be appended to the next version.")
+	Command.Flags().BoolVar(&appendPreReleaseCounter, "pre-release-counter", false, "Specifies if there should be a counter appended to the pre-release tag. It will increase automatically depending on previous pre-releases for the same version.")
+	Command.Flags().StringVar(&prefix, "prefix", "v", "Prefix of the tag name.")
+	Command.Flags().StringVar(&changelogFile, "changelog-file", release.DEFAULT_CHANGELOG_FILE, "Changelog file, relative to the root of the repository.")
+	Command.Flags().StringVar(&changelogStyle, "changelog-style", string(changelog.KEEP_A_CHANGELOG), "Style of the version headers in the changelog file: keepachangelog | conventional")
+	Command.Flags().StringVar(&templateFile, "template", "", "Template file (text/template) for the changelog section. Defaults to the built-in markdown template.")
+	Command.Flags().StringVar(&commitMessage, "commit-message", next.DEFAULT_RELEASE_COMMIT_MESSAGE, "Template of the release commit message.")
+	Command.Flags().BoolVar(&noLinks, "no-links", false, "Do not link commits, issues and versions in the changelog.")
+	Command.Flags().StringVar(&groupByScope, "group-by-scope", "", "Group the entries of each section by scope in markdown: headings | bold. Overrides the grouping of the configuration file.")
+	Command.Flags().BoolVar(&contributorsSection, "contributors", false, "Render a section with the contributors in markdown. Bots are excluded.")
+	Command.Flags().BoolVar(&securityPatch, "security-patch", false, "Enforce at least a patch release if there are security fixes (commits of the type security or with CVE or GHSA references in their footers). Overrides the security.patch option of the configuration file.")
+	Command.Flags().IntVar(&securityMaxUnreleasedCommits, "security-max-unreleased-commits", -1, "Fail if a security fix is followed by more commits since the latest version. 0 disables the check. Overrides the security.max_unreleased_commits option of the configuration file.")
+	Command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the planned changes without changing anything.")
+}
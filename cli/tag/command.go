@@ -0,0 +1,75 @@
+package tag
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/tag"
+	"github.com/spf13/cobra"
+)
+
+var stable bool
+var majorVersionFilter int
+var preReleaseTag string
+var appendPreReleaseCounter bool
+var releaseCommitMessage string
+var prefix string
+var maxAttempts int
+var pushRemote string
+
+var Command = cobra.Command{
+	Use:   "tag",
+	Short: "tags HEAD with the version which should be used for the next release",
+	Long: `This command calculates the next semantic version like the "next" command and creates a tag for it on HEAD. The tag is only created if it does not exist yet. If the tag was created concurrently by another process, the next version is recalculated and the tag creation is retried for pre-releases with a counter. Releases fail instead.
+
+With --push the new tag (and only this tag) is pushed to a remote. Authentication uses the SSH agent for SSH remotes. HTTP(S) remotes use credentials from the remote URL, a token from one of the environment variables GIT_SEMVER_TOKEN (with optional GIT_SEMVER_USERNAME), GITHUB_TOKEN, GITLAB_TOKEN or CI_JOB_TOKEN, or the configured git credential helpers.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		version, err := tag.Tag(tag.TagOptions{
+			NextOptions: next.NextOptions{
+				Workdir:            common_opts.Workdir,
+				Stable:             stable,
+				MajorVersionFilter: majorVersionFilter,
+				PreReleaseOptions: semver.PreReleaseOptions{
+					Label:         preReleaseTag,
+					AppendCounter: appendPreReleaseCounter,
+				},
+				ReleaseCommitMessage: releaseCommitMessage,
+				Parser:               project.Parser,
+				Ignore:               project.Ignore,
+				Overrides:            project.Overrides,
+				SecurityPatch:        project.Config.Security.Patch,
+			},
+			Prefix:      prefix,
+			Remote:      pushRemote,
+			MaxAttempts: maxAttempts,
+		})
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		fmt.Print(version.ToString())
+
+	},
+}
+
+func init() {
+	Command.Flags().BoolVar(&stable, "stable", true, "Specifies if this project is considered stable. Setting this to false will cause the major version to be 0. This command will fail if there is already a major version greater than 0.")
+	Command.Flags().IntVar(&majorVersionFilter, "major-version", -1, "Only consider tags with this specific major version.")
+	Command.Flags().StringVar(&preReleaseTag, "pre-release-tag", "", "Specifies a pre-release tag which should be appended to the next version.")
+	Command.Flags().BoolVar(&appendPreReleaseCounter, "pre-release-counter", false, "Specifies if there should be a counter appended to the pre-release tag. It will increase automatically depending on previous pre-releases for the same version.")
+	Command.Flags().StringVar(&releaseCommitMessage, "release-commit-message", next.DEFAULT_RELEASE_COMMIT_MESSAGE, "Template of release commit messages created by the release command. Matching commits are skipped.")
+	Command.Flags().StringVar(&prefix, "prefix", "v", "Prefix of the tag name.")
+	Command.Flags().StringVar(&pushRemote, "push", "", "Push the new tag to the specified remote. (default remote if no value is specified: origin)")
+	Command.Flags().Lookup("push").NoOptDefVal = "origin"
+	Command.Flags().IntVar(&maxAttempts, "max-attempts", tag.DEFAULT_MAX_ATTEMPTS, "Maximum number of attempts to create a pre-release tag, if it was created concurrently.")
+}
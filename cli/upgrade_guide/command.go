@@ -0,0 +1,101 @@
+package upgrade_guide
+
+import (
+	"fmt"
+	"github.com/psanetra/git-semver/cli/common_opts"
+	"github.com/psanetra/git-semver/contributors"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/upgrade_guide"
+	"github.com/spf13/cobra"
+)
+
+var format string
+var noLinks bool
+
+var Command = cobra.Command{
+	Use:   "upgrade-guide <from> <to>",
+	Short: "prints the breaking changes and deprecations between two versions",
+	Long: `This command prints an upgrade guide for users, who upgrade from the version <from> to the version <to>. It contains the breaking changes ("BREAKING CHANGE" footers and "!" commits) and the deprecation notices ("DEPRECATED" footers) of all versions, which are greater than <from> and not greater than <to>. The changes are grouped by the version, which introduced them, and by scope.
+
+<to> must be a tagged version. The commits of pre-releases are contained in the following release.
+
+The versions may be partial versions like "1.3" or "4". <from> is completed with zeros (e.g. 1.3.0) and <to> is resolved to the greatest released version with its major and minor version (e.g. 4.1.2 for 4.1).`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		from, to, err := upgrade_guide.ParseVersions(common_opts.Workdir, args[0], args[1])
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		outputFormat, err := release_notes.ParseFormat(format)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		project, err := common_opts.LoadProject()
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		if noLinks {
+			project.Config.Links.Disabled = true
+		}
+
+		repoLinks, err := links.Resolve(common_opts.Workdir, project.Config.Links)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		parser, err := references.NewParser(project.Config.References)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		collector, err := contributors.NewCollector(common_opts.Workdir, project.Config.Changelog.Contributors)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		layout, err := release_notes.NewLayout(project.Config.Changelog)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		guide, err := upgrade_guide.New(upgrade_guide.Options{
+			Workdir:      common_opts.Workdir,
+			From:         from,
+			To:           to,
+			Ignore:       project.Ignore,
+			ReleaseNotes: release_notes.Options{References: parser, Layout: layout, Contributors: collector, Parser: project.Parser, Overrides: project.Overrides},
+			Links:        repoLinks,
+		})
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		output, err := guide.Render(outputFormat)
+
+		if err != nil {
+			logger.Logger.Fatalln(err)
+		}
+
+		fmt.Print(output)
+	},
+}
+
+func init() {
+	Command.Flags().StringVar(&format, "format", string(release_notes.MARKDOWN), "Output format: markdown | json")
+	Command.Flags().BoolVar(&noLinks, "no-links", false, "Do not link commits, issues and versions.")
+}
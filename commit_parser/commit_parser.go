@@ -0,0 +1,300 @@
+package commit_parser
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"regexp"
+	"strings"
+)
+
+// Parser parses commit messages of a commit message convention
+type Parser interface {
+	// Parse returns an error if the message does not follow the convention
+	Parse(message string) (*conventional_commits.ConventionalCommitMessage, error)
+}
+
+type Preset string
+
+const (
+	// CONVENTIONAL parses Conventional Commits (e.g. "feat(api): Add endpoint")
+	CONVENTIONAL Preset = "conventional"
+	// GITMOJI parses gitmoji commits (e.g. ":sparkles: Add endpoint" or "✨ (api): Add endpoint")
+	GITMOJI Preset = "gitmoji"
+	// TICKET_PREFIXED parses Conventional Commits with a ticket prefix (e.g. "PROJ-12 feat(api): Add endpoint")
+	TICKET_PREFIXED Preset = "ticket-prefixed"
+	// BRACKET_TAG parses commits with a tag in brackets (e.g. "[FEATURE] Add endpoint" or "[FIX][api] Fix endpoint")
+	BRACKET_TAG Preset = "bracket-tag"
+	// CUSTOM parses the header with a configured regex
+	CUSTOM Preset = "custom"
+)
+
+var Presets = []Preset{CONVENTIONAL, GITMOJI, TICKET_PREFIXED, BRACKET_TAG, CUSTOM}
+
+// DEFAULT_TICKET_PATTERN matches Jira keys (e.g. "PROJ-12")
+const DEFAULT_TICKET_PATTERN = `[A-Z][A-Z0-9_]+-\d+`
+
+// TICKET_FOOTER_TOKEN is the footer token, which contains the ticket of TICKET_PREFIXED commits
+const TICKET_FOOTER_TOKEN = "Refs"
+
+func ParsePreset(preset string) (Preset, error) {
+	for _, p := range Presets {
+		if string(p) == preset {
+			return p, nil
+		}
+	}
+
+	var names []string
+
+	for _, p := range Presets {
+		names = append(names, string(p))
+	}
+
+	return "", errors.Errorf("Unknown parser preset \"%s\" (expected one of %s)", preset, strings.Join(names, ", "))
+}
+
+// Returns the parser of the configured preset and mode. CONVENTIONAL is used if no preset is configured. Bodies and
+// footers are parsed with the configured mode (conventional_commits.LENIENT if no mode is configured).
+func New(cfg config.ParserConfig) (Parser, error) {
+
+	mode := conventional_commits.LENIENT
+
+	if cfg.Mode != "" {
+		var err error
+		mode, err = conventional_commits.ParseMode(cfg.Mode)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	preset := CONVENTIONAL
+
+	if cfg.Preset != "" {
+		var err error
+		preset, err = ParsePreset(cfg.Preset)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch preset {
+	case GITMOJI:
+		return &headerParser{parseHeader: gitmojiHeader, types: cfg.Types, mode: mode}, nil
+	case TICKET_PREFIXED:
+		return newTicketPrefixedParser(cfg.TicketPattern, mode)
+	case BRACKET_TAG:
+		return &headerParser{parseHeader: bracketTagHeader, types: cfg.Types, mode: mode}, nil
+	case CUSTOM:
+		return newCustomParser(cfg.Regex, cfg.Types, mode)
+	}
+
+	return conventionalParser{mode: mode}, nil
+}
+
+// Returns the lenient parser of Conventional Commits.
+func Default() Parser {
+	return conventionalParser{mode: conventional_commits.LENIENT}
+}
+
+// Returns the default parser if parser is nil.
+func OrDefault(parser Parser) Parser {
+	if parser == nil {
+		return Default()
+	}
+
+	return parser
+}
+
+type conventionalParser struct {
+	mode conventional_commits.Mode
+}
+
+func (p conventionalParser) Parse(message string) (*conventional_commits.ConventionalCommitMessage, error) {
+	return conventional_commits.ParseCommitMessageWithMode(message, p.mode)
+}
+
+// header contains the fields of the first line of a commit message
+type header struct {
+	changeType  string
+	scope       string
+	breaking    bool
+	description string
+}
+
+// headerParser parses the first line with parseHeader and the body and the footers like Conventional Commits
+type headerParser struct {
+	// parseHeader parses the line and maps its type with types (see config.ParserConfig.Types)
+	parseHeader func(line string, types map[string]string) (*header, error)
+	types       map[string]string
+	mode        conventional_commits.Mode
+}
+
+func (p *headerParser) Parse(message string) (*conventional_commits.ConventionalCommitMessage, error) {
+
+	line, rest, _ := strings.Cut(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+
+	h, err := p.parseHeader(strings.TrimSpace(line), p.types)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withHeader(h.changeType, h.scope, h.breaking, h.description, rest, p.mode)
+}
+
+var changeTypeRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// Parses a conventional commit message with the header fields and the rest of the message (body and footers) in mode.
+func withHeader(changeType string, scope string, breaking bool, description string, rest string, mode conventional_commits.Mode) (*conventional_commits.ConventionalCommitMessage, error) {
+
+	if !changeTypeRegex.MatchString(changeType) {
+		return nil, errors.Errorf("Invalid change type \"%s\"", changeType)
+	}
+
+	if strings.ContainsAny(scope, "()\n") {
+		return nil, errors.Errorf("Invalid scope \"%s\"", scope)
+	}
+
+	conventionalHeader := strings.ToLower(changeType)
+
+	if scope != "" {
+		conventionalHeader += "(" + scope + ")"
+	}
+
+	if breaking {
+		conventionalHeader += "!"
+	}
+
+	conventionalHeader += ": " + strings.TrimSpace(description)
+
+	if rest != "" {
+		conventionalHeader += "\n" + rest
+	}
+
+	return conventional_commits.ParseCommitMessageWithMode(conventionalHeader, mode)
+}
+
+// bracketTagTypes map common tags to change types
+var bracketTagTypes = map[string]string{
+	"FEATURE": "feat",
+	"BUGFIX":  "fix",
+	"BUG":     "fix",
+	"HOTFIX":  "fix",
+	"DOC":     "docs",
+}
+
+var bracketTagRegex = regexp.MustCompile(`^\[(?P<tag>[^\]]+)\](?:\[(?P<scope>[^\]]+)\])?(?P<breaking>!)?:?\s+(?P<description>\S.*)$`)
+
+func bracketTagHeader(line string, types map[string]string) (*header, error) {
+
+	match := bracketTagRegex.FindStringSubmatch(line)
+
+	if match == nil {
+		return nil, errors.Errorf("Could not parse commit message \"%s\" (expected \"[TAG] description\")", line)
+	}
+
+	tag := strings.TrimSpace(match[1])
+	changeType := strings.ToLower(tag)
+	breaking := match[3] == "!"
+
+	if mapped, ok := types[tag]; ok {
+		changeType = mapped
+	} else if mapped, ok := bracketTagTypes[strings.ToUpper(tag)]; ok {
+		changeType = mapped
+	} else if strings.EqualFold(tag, "BREAKING") {
+		changeType = string(conventional_commits.FEATURE)
+		breaking = true
+	}
+
+	return &header{changeType: changeType, scope: strings.TrimSpace(match[2]), breaking: breaking, description: match[4]}, nil
+}
+
+// ticketPrefixedParser removes the ticket prefix and adds the ticket as TICKET_FOOTER_TOKEN footer
+type ticketPrefixedParser struct {
+	prefixRegex *regexp.Regexp
+	mode        conventional_commits.Mode
+}
+
+func newTicketPrefixedParser(ticketPattern string, mode conventional_commits.Mode) (Parser, error) {
+
+	if ticketPattern == "" {
+		ticketPattern = DEFAULT_TICKET_PATTERN
+	}
+
+	prefixRegex, err := regexp.Compile(`^\[?(` + ticketPattern + `)\]?:?\s+`)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not parse ticket pattern "+ticketPattern)
+	}
+
+	return &ticketPrefixedParser{prefixRegex: prefixRegex, mode: mode}, nil
+}
+
+func (p *ticketPrefixedParser) Parse(message string) (*conventional_commits.ConventionalCommitMessage, error) {
+
+	match := p.prefixRegex.FindStringSubmatchIndex(message)
+
+	if match == nil {
+		return conventional_commits.ParseCommitMessageWithMode(message, p.mode)
+	}
+
+	commitMessage, err := conventional_commits.ParseCommitMessageWithMode(message[match[1]:], p.mode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := message[match[2]:match[3]]
+	commitMessage.Footers[TICKET_FOOTER_TOKEN] = append([]string{ticket}, commitMessage.Footers[TICKET_FOOTER_TOKEN]...)
+
+	return commitMessage, nil
+}
+
+// newCustomParser creates a parser for headers matching the regex with the named groups "type" and "description" and
+// the optional named groups "scope" and "breaking" (any non-empty match is a breaking change).
+func newCustomParser(pattern string, types map[string]string, mode conventional_commits.Mode) (Parser, error) {
+
+	if pattern == "" {
+		return nil, errors.New("The custom parser preset requires a regex")
+	}
+
+	regex, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not parse parser regex "+pattern)
+	}
+
+	if regex.SubexpIndex("type") < 0 || regex.SubexpIndex("description") < 0 {
+		return nil, errors.Errorf("The parser regex %s requires the named groups \"type\" and \"description\"", pattern)
+	}
+
+	return &headerParser{
+		parseHeader: func(line string, types map[string]string) (*header, error) {
+			match := regex.FindStringSubmatch(line)
+
+			if match == nil {
+				return nil, errors.Errorf("Could not parse commit message \"%s\" (expected a match of %s)", line, pattern)
+			}
+
+			group := func(name string) string {
+				if i := regex.SubexpIndex(name); i >= 0 {
+					return match[i]
+				}
+
+				return ""
+			}
+
+			changeType := group("type")
+
+			if mapped, ok := types[changeType]; ok {
+				changeType = mapped
+			}
+
+			return &header{changeType: changeType, scope: group("scope"), breaking: group("breaking") != "", description: group("description")}, nil
+		},
+		types: types,
+		mode:  mode,
+	}, nil
+}
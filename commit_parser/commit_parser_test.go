@@ -0,0 +1,207 @@
+package commit_parser
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newParser(t *testing.T, cfg config.ParserConfig) Parser {
+	parser, err := New(cfg)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parser
+}
+
+func TestNew_should_return_conventional_parser_by_default(t *testing.T) {
+	message, err := newParser(t, config.ParserConfig{}).Parse("feat(api): Add endpoint")
+
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FEATURE, message.ChangeType)
+	assert.Equal(t, "api", message.Scope)
+	assert.Equal(t, "Add endpoint", message.Description)
+}
+
+func TestNew_should_fail_on_unknown_preset(t *testing.T) {
+	_, err := New(config.ParserConfig{Preset: "angular"})
+
+	assert.EqualError(t, err, "Unknown parser preset \"angular\" (expected one of conventional, gitmoji, ticket-prefixed, bracket-tag, custom)")
+}
+
+func TestNew_should_use_configured_mode(t *testing.T) {
+	_, err := newParser(t, config.ParserConfig{}).Parse("fix:Fix crash")
+	assert.Nil(t, err)
+
+	_, err = newParser(t, config.ParserConfig{Mode: "strict"}).Parse("fix:Fix crash")
+	assert.NotNil(t, err)
+
+	_, err = newParser(t, config.ParserConfig{Preset: "ticket-prefixed", Mode: "strict"}).Parse("PROJ-12 fix:Fix crash")
+	assert.NotNil(t, err)
+
+	_, err = New(config.ParserConfig{Mode: "pedantic"})
+	assert.EqualError(t, err, "Unknown parser mode \"pedantic\" (expected lenient or strict)")
+}
+
+func TestGitmoji_should_parse_codes_and_unicode_gitmojis(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "gitmoji"})
+
+	feature, err := parser.Parse(":sparkles: (api) Add endpoint\n\nBody\n\nCloses #12")
+	assert.Nil(t, err)
+	assert.Equal(t, &conventional_commits.ConventionalCommitMessage{
+		ChangeType:  conventional_commits.FEATURE,
+		Scope:       "api",
+		Description: "Add endpoint",
+		Body:        "Body",
+		Footers:     map[string][]string{"Closes": {"12"}},
+	}, feature)
+
+	fix, err := parser.Parse("🐛 Fix crash")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FIX, fix.ChangeType)
+	assert.Equal(t, "Fix crash", fix.Description)
+
+	refactoring, err := parser.Parse("♻️ Simplify parser")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.ChangeType("refactor"), refactoring.ChangeType)
+}
+
+func TestGitmoji_should_detect_breaking_changes(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "gitmoji"})
+
+	boom, err := parser.Parse("💥 Remove v1 api")
+	assert.Nil(t, err)
+	assert.True(t, boom.ContainsBreakingChange)
+	assert.Equal(t, conventional_commits.FEATURE, boom.ChangeType)
+
+	footer, err := parser.Parse(":recycle: Rename option\n\nBREAKING CHANGE: The option was renamed.")
+	assert.Nil(t, err)
+	assert.True(t, footer.ContainsBreakingChange)
+}
+
+func TestGitmoji_should_map_configured_types(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "gitmoji", Types: map[string]string{":rocket:": "deploy", ":bug:": "bugfix"}})
+
+	deploy, err := parser.Parse(":rocket: Deploy to production")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.ChangeType("deploy"), deploy.ChangeType)
+
+	fix, err := parser.Parse("🐛 Fix crash")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.ChangeType("bugfix"), fix.ChangeType)
+}
+
+func TestGitmoji_should_fail_on_unknown_gitmoji(t *testing.T) {
+	_, err := newParser(t, config.ParserConfig{Preset: "gitmoji"}).Parse(":unicorn: Add magic")
+
+	assert.EqualError(t, err, "Could not parse commit message \":unicorn: Add magic\" (unknown gitmoji :unicorn:)")
+}
+
+func TestTicketPrefixed_should_add_ticket_footer(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "ticket-prefixed"})
+
+	message, err := parser.Parse("PROJ-12 feat(api): Add endpoint\n\nRefs: PROJ-10")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FEATURE, message.ChangeType)
+	assert.Equal(t, "Add endpoint", message.Description)
+	assert.Equal(t, []string{"PROJ-12", "PROJ-10"}, message.Footers[TICKET_FOOTER_TOKEN])
+
+	bracketed, err := parser.Parse("[PROJ-13]: fix: Fix crash")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FIX, bracketed.ChangeType)
+	assert.Equal(t, []string{"PROJ-13"}, bracketed.Footers[TICKET_FOOTER_TOKEN])
+
+	withoutTicket, err := parser.Parse("fix: Fix crash")
+	assert.Nil(t, err)
+	assert.Nil(t, withoutTicket.Footers[TICKET_FOOTER_TOKEN])
+}
+
+func TestTicketPrefixed_should_use_ticket_pattern(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "ticket-prefixed", TicketPattern: `#\d+`})
+
+	message, err := parser.Parse("#42 fix: Fix crash")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#42"}, message.Footers[TICKET_FOOTER_TOKEN])
+
+	_, err = New(config.ParserConfig{Preset: "ticket-prefixed", TicketPattern: "("})
+	assert.NotNil(t, err)
+}
+
+func TestBracketTag_should_parse_tags(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "bracket-tag"})
+
+	feature, err := parser.Parse("[FEATURE] Add endpoint")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FEATURE, feature.ChangeType)
+	assert.Equal(t, "Add endpoint", feature.Description)
+
+	fix, err := parser.Parse("[Bugfix][api] Fix crash")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FIX, fix.ChangeType)
+	assert.Equal(t, "api", fix.Scope)
+
+	chore, err := parser.Parse("[chore]: Update dependencies")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.ChangeType("chore"), chore.ChangeType)
+
+	breaking, err := parser.Parse("[BREAKING] Remove v1 api")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FEATURE, breaking.ChangeType)
+	assert.True(t, breaking.ContainsBreakingChange)
+
+	indicator, err := parser.Parse("[FIX]! Change defaults")
+	assert.Nil(t, err)
+	assert.True(t, indicator.ContainsBreakingChange)
+
+	_, err = parser.Parse("Add endpoint")
+	assert.EqualError(t, err, "Could not parse commit message \"Add endpoint\" (expected \"[TAG] description\")")
+}
+
+func TestBracketTag_should_map_configured_types(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{Preset: "bracket-tag", Types: map[string]string{"ENH": "feat"}})
+
+	message, err := parser.Parse("[ENH] Improve search")
+
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FEATURE, message.ChangeType)
+}
+
+func TestCustom_should_parse_with_regex(t *testing.T) {
+	parser := newParser(t, config.ParserConfig{
+		Preset: "custom",
+		Regex:  `^(?P<type>\w+)(?P<breaking>!!)? \| (?:(?P<scope>\w+) \| )?(?P<description>.+)$`,
+		Types:  map[string]string{"added": "feat", "fixed": "fix"},
+	})
+
+	feature, err := parser.Parse("added | api | Add endpoint\n\nBody")
+	assert.Nil(t, err)
+	assert.Equal(t, &conventional_commits.ConventionalCommitMessage{
+		ChangeType:  conventional_commits.FEATURE,
+		Scope:       "api",
+		Description: "Add endpoint",
+		Body:        "Body",
+		Footers:     map[string][]string{},
+	}, feature)
+
+	breaking, err := parser.Parse("fixed!! | Change defaults")
+	assert.Nil(t, err)
+	assert.Equal(t, conventional_commits.FIX, breaking.ChangeType)
+	assert.True(t, breaking.ContainsBreakingChange)
+
+	_, err = parser.Parse("Add endpoint")
+	assert.NotNil(t, err)
+}
+
+func TestCustom_should_validate_regex(t *testing.T) {
+	_, err := New(config.ParserConfig{Preset: "custom"})
+	assert.EqualError(t, err, "The custom parser preset requires a regex")
+
+	_, err = New(config.ParserConfig{Preset: "custom", Regex: `^(?P<type>\w+): (.+)$`})
+	assert.EqualError(t, err, "The parser regex ^(?P<type>\\w+): (.+)$ requires the named groups \"type\" and \"description\"")
+
+	_, err = New(config.ParserConfig{Preset: "custom", Regex: `(`})
+	assert.NotNil(t, err)
+}
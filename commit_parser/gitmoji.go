@@ -0,0 +1,93 @@
+package commit_parser
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
+)
+
+// gitmojiTypes map gitmoji codes (https://gitmoji.dev) to change types. BREAKING_GITMOJI marks breaking changes.
+var gitmojiTypes = map[string]string{
+	":sparkles:":            "feat",
+	":bug:":                 "fix",
+	":ambulance:":           "fix",
+	":lock:":                "fix",
+	":memo:":                "docs",
+	":art:":                 "style",
+	":lipstick:":            "style",
+	":recycle:":             "refactor",
+	":fire:":                "refactor",
+	":zap:":                 "perf",
+	":white_check_mark:":    "test",
+	":construction_worker:": "ci",
+	":green_heart:":         "ci",
+	":wrench:":              "chore",
+	":arrow_up:":            "chore",
+	":arrow_down:":          "chore",
+	":heavy_plus_sign:":     "chore",
+	":heavy_minus_sign:":    "chore",
+	":package:":             "build",
+	":rewind:":              "revert",
+	BREAKING_GITMOJI:        "feat",
+}
+
+// BREAKING_GITMOJI marks breaking changes
+const BREAKING_GITMOJI = ":boom:"
+
+// gitmojiCodes map the unicode gitmojis (without variation selectors) to their codes
+var gitmojiCodes = map[string]string{
+	"✨": ":sparkles:",
+	"🐛": ":bug:",
+	"🚑": ":ambulance:",
+	"🔒": ":lock:",
+	"📝": ":memo:",
+	"🎨": ":art:",
+	"💄": ":lipstick:",
+	"♻": ":recycle:",
+	"🔥": ":fire:",
+	"⚡": ":zap:",
+	"✅": ":white_check_mark:",
+	"👷": ":construction_worker:",
+	"💚": ":green_heart:",
+	"🔧": ":wrench:",
+	"⬆": ":arrow_up:",
+	"⬇": ":arrow_down:",
+	"➕": ":heavy_plus_sign:",
+	"➖": ":heavy_minus_sign:",
+	"📦": ":package:",
+	"⏪": ":rewind:",
+	"💥": ":boom:",
+}
+
+// VARIATION_SELECTOR is part of some unicode gitmojis (e.g. "♻️")
+const VARIATION_SELECTOR = "\uFE0F"
+
+// gitmojiRegex matches "<gitmoji> [(scope)][:] description" with a gitmoji code or a unicode gitmoji
+var gitmojiRegex = regexp.MustCompile(`^(?P<gitmoji>:[a-z0-9_+\-]+:|[^\s(:]+)\s*(?:\((?P<scope>[^)]+)\))?:?\s+(?P<description>\S.*)$`)
+
+func gitmojiHeader(line string, types map[string]string) (*header, error) {
+
+	match := gitmojiRegex.FindStringSubmatch(line)
+
+	if match == nil {
+		return nil, errors.Errorf("Could not parse commit message \"%s\" (expected \"<gitmoji> description\")", line)
+	}
+
+	code := match[1]
+
+	if c, ok := gitmojiCodes[strings.ReplaceAll(code, VARIATION_SELECTOR, "")]; ok {
+		code = c
+	}
+
+	changeType, ok := types[code]
+
+	if !ok {
+		changeType, ok = gitmojiTypes[code]
+	}
+
+	if !ok {
+		return nil, errors.Errorf("Could not parse commit message \"%s\" (unknown gitmoji %s)", line, match[1])
+	}
+
+	return &header{changeType: changeType, scope: match[2], breaking: code == BREAKING_GITMOJI, description: match[3]}, nil
+}
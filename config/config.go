@@ -0,0 +1,239 @@
+package config
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+)
+
+// DEFAULT_FILE is the name of the project configuration file in the root of the repository
+const DEFAULT_FILE = ".git-semver.yaml"
+
+type Config struct {
+	Bump       BumpConfig       `yaml:"bump"`
+	Generate   GenerateConfig   `yaml:"generate"`
+	Links      LinksConfig      `yaml:"links"`
+	References ReferencesConfig `yaml:"references"`
+	Changelog  ChangelogConfig  `yaml:"changelog"`
+	Parser     ParserConfig     `yaml:"parser"`
+	Ignore     *IgnoreConfig    `yaml:"ignore"`
+	Overrides  OverridesConfig  `yaml:"overrides"`
+	Security   SecurityConfig   `yaml:"security"`
+}
+
+type ParserConfig struct {
+	// Mode of the conventional commits parser: lenient (default) | strict
+	Mode string `yaml:"mode,omitempty"`
+	// Preset is the commit message convention: conventional (default) | gitmoji | ticket-prefixed | bracket-tag | custom
+	Preset string `yaml:"preset,omitempty"`
+	// TicketPattern is the regex of the tickets of the ticket-prefixed preset. Defaults to Jira keys.
+	TicketPattern string `yaml:"ticket_pattern,omitempty"`
+	// Regex of the custom preset with the named groups "type", "description" and the optional groups "scope" and
+	// "breaking"
+	Regex string `yaml:"regex,omitempty"`
+	// Types map gitmojis, bracket tags or types of the custom preset to change types (e.g. "FEATURE: feat")
+	Types map[string]string `yaml:"types,omitempty"`
+}
+
+// IgnoreConfig configures commits, which neither affect the next version nor the release notes. No commits are ignored
+// if the configuration file has no ignore section.
+type IgnoreConfig struct {
+	// Authors contains regexes for "Name <email>" of authors (e.g. "^dependabot")
+	Authors []string `yaml:"authors,omitempty"`
+	// Committers contains regexes for "Name <email>" of committers
+	Committers []string `yaml:"committers,omitempty"`
+	// Messages contains regexes for commit messages. Defaults to patterns for fixup and squash commits and
+	// "[skip release]" markers. An empty list disables the defaults.
+	Messages []string `yaml:"messages"`
+	// Footers contain footer tokens (e.g. "Skip-Release") or footers with a value (e.g. "Release-Note: none")
+	Footers []string `yaml:"footers,omitempty"`
+	// Commits contain hashes or unique hash prefixes of commits
+	Commits []string `yaml:"commits,omitempty"`
+}
+
+// OverridesConfig configures the sources of overrides for the classification of single commits
+type OverridesConfig struct {
+	// File contains the overrides keyed by commit hash. It is relative to the root of the repository. Defaults to
+	// ".git-semver-overrides.yaml".
+	File string `yaml:"file,omitempty"`
+	// NotesRef contains overrides as git notes. Defaults to "refs/notes/semver".
+	NotesRef string `yaml:"notes_ref,omitempty"`
+}
+
+// SecurityConfig configures the handling of security fixes. They are commits of the type "security" and commits, which
+// reference CVE or GHSA IDs in their footers.
+type SecurityConfig struct {
+	// Patch enforces at least a patch release if there are security fixes since the latest version
+	Patch bool `yaml:"patch,omitempty"`
+	// MaxUnreleasedCommits fails the release command and "next --check-security" if a security fix is followed by more
+	// commits. 0 disables the check.
+	MaxUnreleasedCommits int `yaml:"max_unreleased_commits,omitempty"`
+}
+
+type BumpConfig struct {
+	Files []BumpFile `yaml:"files"`
+}
+
+// BumpFile configures a file, which contains the version of the project
+type BumpFile struct {
+	// Path is relative to the root of the repository
+	Path string `yaml:"path"`
+	// Type is detected from the file name if it is empty: json | xml | yaml | toml | regex | plain
+	Type string `yaml:"type,omitempty"`
+	// Selectors locate the version in json (e.g. "$.version"), xml (e.g. "/project/version"), yaml (e.g. "version") and toml (e.g. "package.version") files
+	Selectors []string `yaml:"selectors,omitempty"`
+	// Pattern is a regex for the type "regex". The version is matched by the group "version" or by the first group.
+	Pattern string `yaml:"pattern,omitempty"`
+}
+
+type GenerateConfig struct {
+	Files []GenerateFile `yaml:"files"`
+}
+
+// GenerateFile configures a file, which is rendered from a template
+type GenerateFile struct {
+	// Path of the generated file. It is relative to the root of the repository.
+	Path string `yaml:"path"`
+	// Template is the name of a built-in template (go | c | properties | typescript) or the path of a template file relative to the root of the repository
+	Template string `yaml:"template"`
+	// Vars are passed to the template (e.g. "package" for the built-in go template)
+	Vars map[string]string `yaml:"vars,omitempty"`
+}
+
+// LinksConfig configures the links in release notes. The web url of the repository and the hosting provider are
+// detected from the remote url of "origin" by default.
+type LinksConfig struct {
+	// Disabled turns off all links (e.g. for offline formats)
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Provider overrides the detected hosting provider: github | gitlab | bitbucket | gitea | azure
+	Provider string `yaml:"provider,omitempty"`
+	// URL overrides the web url of the repository (e.g. "https://git.example.com/group/project")
+	URL string `yaml:"url,omitempty"`
+	// Commit is a url template with the placeholders {url} and {hash}, which overrides the template of the provider
+	Commit string `yaml:"commit,omitempty"`
+	// Compare is a url template with the placeholders {url}, {from} and {to}, which overrides the template of the provider
+	Compare string `yaml:"compare,omitempty"`
+	// Issue is a url template with the placeholders {url} and {id}, which overrides the template of the provider
+	Issue string `yaml:"issue,omitempty"`
+	// PullRequest is a url template with the placeholders {url} and {id}, which overrides the template of the provider
+	PullRequest string `yaml:"pull_request,omitempty"`
+}
+
+type ReferencesConfig struct {
+	// Patterns find references to issues and pull requests in commit messages. The built-in patterns "github" and
+	// "gitlab" are used if it is empty.
+	Patterns []ReferencePattern `yaml:"patterns"`
+}
+
+// ReferencePattern configures a pattern for references to issues or pull requests
+type ReferencePattern struct {
+	// Name of a built-in pattern (github | gitlab | jira) or of a custom pattern
+	Name string `yaml:"name"`
+	// Regex matches a reference. The id of the reference is matched by the group "id" or by the first group. Built-in
+	// patterns provide a default regex.
+	Regex string `yaml:"regex,omitempty"`
+	// Type is issue or pull_request. Defaults to issue.
+	Type string `yaml:"type,omitempty"`
+	// URL is a url template with the placeholder {id}. Defaults to the issue or pull request url of the links.
+	URL string `yaml:"url,omitempty"`
+}
+
+type ChangelogConfig struct {
+	// Sections of the release notes in this order. Defaults to "Features" (feat) and "Bug Fixes" (fix).
+	Sections []ChangelogSection `yaml:"sections"`
+	// Hidden change types are not listed in any section. Breaking changes are listed anyway.
+	Hidden []string `yaml:"hidden,omitempty"`
+	// Scopes configures the grouping of the entries of each section by scope
+	Scopes ChangelogScopes `yaml:"scopes"`
+	// Contributors configures the contributors of the release notes
+	Contributors ContributorsConfig `yaml:"contributors"`
+	// Duplicates defines which commits are merged into a single entry: any (default, same header or same changes) |
+	// changes (same changes) | header (same header) | none
+	Duplicates string `yaml:"duplicates,omitempty"`
+}
+
+type ContributorsConfig struct {
+	// Section renders a contributors section in the markdown format
+	Section bool `yaml:"section,omitempty"`
+	// Exclude contains regexes for names and emails of contributors, which are not listed (e.g. bots). Defaults to
+	// patterns for bots like Renovate and Dependabot.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Handles map emails to handles (e.g. "jane@example.com: '@jane'")
+	Handles map[string]string `yaml:"handles,omitempty"`
+}
+
+type ChangelogScopes struct {
+	// Grouping of the entries by scope: headings | bold. The entries are not grouped if it is empty.
+	Grouping string `yaml:"grouping,omitempty"`
+	// Order of the groups (scopes or aliases). Other groups follow in alphabetical order.
+	Order []string `yaml:"order,omitempty"`
+	// Aliases map scopes to group titles (e.g. "ui: Frontend")
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	// NoScope is the title of the group of entries without scope. Defaults to "Other".
+	NoScope string `yaml:"no_scope,omitempty"`
+}
+
+type ChangelogSection struct {
+	Title string   `yaml:"title"`
+	Types []string `yaml:"types,omitempty"`
+	// Other makes this section the catch-all for non-conventional commits and for all change types, which are neither
+	// hidden nor contained in another section
+	Other bool `yaml:"other,omitempty"`
+	// Security makes this section the section of security fixes. They are commits of the type "security" and commits of
+	// all other types, which reference CVE or GHSA IDs in their footers.
+	Security bool `yaml:"security,omitempty"`
+}
+
+// Loads the configuration file. If file is empty, DEFAULT_FILE in the root of the repository in workdir is loaded if it exists.
+func Load(workdir string, file string) (*Config, error) {
+
+	if file == "" {
+		root, err := RepositoryRoot(workdir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		file = filepath.Join(root, DEFAULT_FILE)
+
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+	}
+
+	content, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read configuration file")
+	}
+
+	config := &Config{}
+
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return nil, errors.WithMessage(err, "Could not parse configuration file "+file)
+	}
+
+	return config, nil
+}
+
+// Returns the root directory of the worktree of the repository in workdir.
+func RepositoryRoot(workdir string) (string, error) {
+
+	repo, err := git.PlainOpenWithOptions(workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Could not open git repository")
+	}
+
+	worktree, err := repo.Worktree()
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Could not open worktree")
+	}
+
+	return worktree.Filesystem.Root(), nil
+}
@@ -0,0 +1,168 @@
+package contributors
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Contributor is an author or co-author of commits
+type Contributor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Handle is the configured handle or the GitHub handle of a GitHub noreply email (e.g. "@octocat")
+	Handle string `json:"handle,omitempty"`
+	// Commits is the number of commits, which were authored or co-authored by the contributor
+	Commits int `json:"commits"`
+}
+
+// DEFAULT_EXCLUDE contains patterns for the names and emails of bots
+var DEFAULT_EXCLUDE = []string{`\[bot\]`, `(?i)^renovate`, `(?i)^dependabot`}
+
+var coAuthoredByRegex = regexp.MustCompile(`(?mi)^Co-authored-by:[ \t]*([^<\n]*?)[ \t]*<([^>\n]+)>[ \t]*$`)
+var githubNoreplyEmailRegex = regexp.MustCompile(`(?i)^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
+
+// Collector collects the contributors of commits
+type Collector struct {
+	mailmap *Mailmap
+	exclude []*regexp.Regexp
+	handles map[string]string
+}
+
+// Creates a collector with the mailmap of the repository in workdir. DEFAULT_EXCLUDE is used if there are no exclude
+// patterns configured.
+func NewCollector(workdir string, cfg config.ContributorsConfig) (*Collector, error) {
+
+	root, err := config.RepositoryRoot(workdir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mailmap, err := LoadMailmap(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newCollector(mailmap, cfg)
+}
+
+// Returns a collector without mailmap, which excludes DEFAULT_EXCLUDE.
+func DefaultCollector() *Collector {
+	collector, err := newCollector(&Mailmap{}, config.ContributorsConfig{})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return collector
+}
+
+func newCollector(mailmap *Mailmap, cfg config.ContributorsConfig) (*Collector, error) {
+
+	collector := &Collector{mailmap: mailmap, handles: make(map[string]string)}
+
+	patterns := cfg.Exclude
+
+	if len(patterns) == 0 {
+		patterns = DEFAULT_EXCLUDE
+	}
+
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not parse contributor exclude pattern "+pattern)
+		}
+
+		collector.exclude = append(collector.exclude, regex)
+	}
+
+	for email, handle := range cfg.Handles {
+		collector.handles[strings.ToLower(email)] = handle
+	}
+
+	return collector, nil
+}
+
+// Returns the distinct authors and co-authors (from "Co-authored-by" trailers) of the commits. The contributors are
+// ordered by their number of commits and by name.
+func (c *Collector) Collect(commits []*object.Commit) []*Contributor {
+
+	contributors := []*Contributor{}
+	byEmail := make(map[string]*Contributor)
+
+	for _, commit := range commits {
+		counted := make(map[*Contributor]bool)
+
+		identities := [][2]string{{commit.Author.Name, commit.Author.Email}}
+
+		for _, match := range coAuthoredByRegex.FindAllStringSubmatch(commit.Message, -1) {
+			identities = append(identities, [2]string{match[1], match[2]})
+		}
+
+		for _, identity := range identities {
+			name, email := c.mailmap.Resolve(identity[0], identity[1])
+
+			if c.isExcluded(name, email) {
+				continue
+			}
+
+			key := strings.ToLower(email)
+
+			if key == "" {
+				key = name
+			}
+
+			contributor, exists := byEmail[key]
+
+			if !exists {
+				contributor = &Contributor{Name: name, Email: email, Handle: c.handle(email)}
+				byEmail[key] = contributor
+				contributors = append(contributors, contributor)
+			}
+
+			if !counted[contributor] {
+				counted[contributor] = true
+				contributor.Commits++
+			}
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+
+		return strings.ToLower(contributors[i].Name) < strings.ToLower(contributors[j].Name)
+	})
+
+	return contributors
+}
+
+func (c *Collector) isExcluded(name string, email string) bool {
+	for _, regex := range c.exclude {
+		if regex.MatchString(name) || regex.MatchString(email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Collector) handle(email string) string {
+
+	if handle, ok := c.handles[strings.ToLower(email)]; ok {
+		return handle
+	}
+
+	if match := githubNoreplyEmailRegex.FindStringSubmatch(email); match != nil {
+		return "@" + match[1]
+	}
+
+	return ""
+}
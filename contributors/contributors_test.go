@@ -0,0 +1,71 @@
+package contributors
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func commit(name string, email string, message string) *object.Commit {
+	return &object.Commit{
+		Author:  object.Signature{Name: name, Email: email},
+		Message: message,
+	}
+}
+
+func TestCollect_should_count_authors_and_co_authors(t *testing.T) {
+	collector, err := newCollector(ParseMailmap("Jane Doe <jane@example.com> <jane@old.example.com>"), config.ContributorsConfig{})
+	assert.Nil(t, err)
+
+	result := collector.Collect([]*object.Commit{
+		commit("John", "john@example.com", "feat: Feature\n\nCo-authored-by: Jane <jane@old.example.com>"),
+		commit("Jane Doe", "jane@example.com", "fix: Fix\n\nCo-authored-by: Jane Doe <jane@example.com>"),
+		commit("Octo Cat", "1234+octocat@users.noreply.github.com", "Non-conventional commit"),
+	})
+
+	assert.Equal(t, []*Contributor{
+		{Name: "Jane Doe", Email: "jane@example.com", Commits: 2},
+		{Name: "John", Email: "john@example.com", Commits: 1},
+		{Name: "Octo Cat", Email: "1234+octocat@users.noreply.github.com", Handle: "@octocat", Commits: 1},
+	}, result)
+}
+
+func TestCollect_should_exclude_bots_by_default(t *testing.T) {
+	result := DefaultCollector().Collect([]*object.Commit{
+		commit("renovate[bot]", "29139614+renovate[bot]@users.noreply.github.com", "chore(deps): Update"),
+		commit("dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", "chore(deps): Bump"),
+		commit("Renovate Bot", "bot@renovateapp.com", "chore(deps): Update"),
+		commit("Jane", "jane@example.com", "fix: Fix\n\nCo-authored-by: github-actions[bot] <41898282+github-actions[bot]@users.noreply.github.com>"),
+	})
+
+	assert.Equal(t, []*Contributor{{Name: "Jane", Email: "jane@example.com", Commits: 1}}, result)
+}
+
+func TestCollect_should_use_configured_exclude_patterns_and_handles(t *testing.T) {
+	collector, err := newCollector(&Mailmap{}, config.ContributorsConfig{
+		Exclude: []string{`^ci@`},
+		Handles: map[string]string{"Jane@Example.com": "@jane"},
+	})
+	assert.Nil(t, err)
+
+	result := collector.Collect([]*object.Commit{
+		commit("CI", "ci@example.com", "chore: Build"),
+		commit("Jane", "jane@example.com", "fix: Fix"),
+		commit("dependabot[bot]", "dependabot@example.com", "chore(deps): Bump"),
+	})
+
+	assert.Equal(t, []*Contributor{
+		{Name: "dependabot[bot]", Email: "dependabot@example.com", Commits: 1},
+		{Name: "Jane", Email: "jane@example.com", Handle: "@jane", Commits: 1},
+	}, result)
+}
+
+func TestNewCollector_should_fail_on_invalid_exclude_pattern(t *testing.T) {
+	_, dir := test_utils.InitRepo(t)
+
+	_, err := NewCollector(dir, config.ContributorsConfig{Exclude: []string{"("}})
+
+	assert.ErrorContains(t, err, "Could not parse contributor exclude pattern (")
+}
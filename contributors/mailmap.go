@@ -0,0 +1,110 @@
+package contributors
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MAILMAP_FILE is the name of the mailmap file in the root of the repository
+const MAILMAP_FILE = ".mailmap"
+
+// Mailmap maps the names and emails of commits to canonical identities (see git-check-mailmap)
+type Mailmap struct {
+	entries []*mailmapEntry
+}
+
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	// commitName is empty if the entry matches all names
+	commitName  string
+	commitEmail string
+}
+
+// mailmapLineRegex matches "[Proper Name] [<proper@email>] [Commit Name] <commit@email>"
+var mailmapLineRegex = regexp.MustCompile(`^([^<]*)<([^>]*)>(?:([^<]*)<([^>]*)>)?\s*$`)
+
+// Parses the content of a mailmap file. Invalid lines are ignored.
+func ParseMailmap(content string) *Mailmap {
+
+	mailmap := &Mailmap{}
+
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		match := mailmapLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+
+		if match == nil {
+			continue
+		}
+
+		entry := &mailmapEntry{properName: strings.TrimSpace(match[1])}
+
+		if match[4] == "" && match[3] == "" {
+			// "Proper Name <commit@email>"
+			entry.commitEmail = match[2]
+		} else {
+			entry.properEmail = match[2]
+			entry.commitName = strings.TrimSpace(match[3])
+			entry.commitEmail = match[4]
+		}
+
+		mailmap.entries = append(mailmap.entries, entry)
+	}
+
+	return mailmap
+}
+
+// Loads MAILMAP_FILE in the root directory of the repository. The mailmap is empty if the file does not exist.
+func LoadMailmap(root string) (*Mailmap, error) {
+
+	content, err := os.ReadFile(filepath.Join(root, MAILMAP_FILE))
+
+	if os.IsNotExist(err) {
+		return &Mailmap{}, nil
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "Could not read "+MAILMAP_FILE)
+	}
+
+	return ParseMailmap(string(content)), nil
+}
+
+// Returns the canonical name and email. Entries with a commit name take precedence over entries with only a commit
+// email. Later entries take precedence over earlier entries.
+func (m *Mailmap) Resolve(name string, email string) (string, string) {
+
+	var match *mailmapEntry
+
+	for _, entry := range m.entries {
+		if !strings.EqualFold(entry.commitEmail, email) {
+			continue
+		}
+
+		if entry.commitName != "" && !strings.EqualFold(entry.commitName, name) {
+			continue
+		}
+
+		if match == nil || entry.commitName != "" || match.commitName == "" {
+			match = entry
+		}
+	}
+
+	if match == nil {
+		return name, email
+	}
+
+	if match.properName != "" {
+		name = match.properName
+	}
+
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+
+	return name, email
+}
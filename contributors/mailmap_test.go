@@ -0,0 +1,53 @@
+package contributors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMailmap_should_resolve_all_entry_forms(t *testing.T) {
+	mailmap := ParseMailmap(`# comment
+Jane Doe <jane@old.example.com>
+<jane@example.com> <jane@work.example.com>
+Jane Doe <jane@example.com> <j.doe@example.com> # trailing comment
+John Doe <john@example.com> Johnny <shared@example.com>
+invalid line
+`)
+
+	assertResolved := func(name, email, expectedName, expectedEmail string) {
+		actualName, actualEmail := mailmap.Resolve(name, email)
+		assert.Equal(t, expectedName, actualName)
+		assert.Equal(t, expectedEmail, actualEmail)
+	}
+
+	assertResolved("jane", "jane@old.example.com", "Jane Doe", "jane@old.example.com")
+	assertResolved("jane", "JANE@work.example.com", "jane", "jane@example.com")
+	assertResolved("jd", "j.doe@example.com", "Jane Doe", "jane@example.com")
+	assertResolved("johnny", "shared@example.com", "John Doe", "john@example.com")
+	assertResolved("Other", "shared@example.com", "Other", "shared@example.com")
+	assertResolved("Unknown", "unknown@example.com", "Unknown", "unknown@example.com")
+}
+
+func TestLoadMailmap_should_return_empty_mailmap_without_file(t *testing.T) {
+	mailmap, err := LoadMailmap(t.TempDir())
+
+	assert.Nil(t, err)
+
+	name, email := mailmap.Resolve("Jane", "jane@example.com")
+	assert.Equal(t, "Jane", name)
+	assert.Equal(t, "jane@example.com", email)
+}
+
+func TestLoadMailmap_should_read_mailmap_of_root(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, MAILMAP_FILE), []byte("Jane Doe <jane@example.com>\n"), 0644))
+
+	mailmap, err := LoadMailmap(dir)
+
+	assert.Nil(t, err)
+
+	name, _ := mailmap.Resolve("jane", "jane@example.com")
+	assert.Equal(t, "Jane Doe", name)
+}
@@ -11,9 +11,12 @@ const (
 	DOCS     ChangeType = "docs"
 	REFACTOR ChangeType = "refactor"
 	CI       ChangeType = "ci"
+	// SECURITY marks security fixes. Commits of other types are security fixes if they reference advisories.
+	SECURITY ChangeType = "security"
 )
 
 var ChangeTypePriorities = map[ChangeType]int{
+	SECURITY: 11,
 	FEATURE:  10,
 	FIX:      9,
 	PERF:     8,
@@ -23,3 +26,22 @@ var ChangeTypePriorities = map[ChangeType]int{
 	STYLE:    4,
 	REFACTOR: 3,
 }
+
+// Returns a copy of ChangeTypePriorities, in which the change types have descending priorities, which are higher than
+// the priorities of all other change types. The order of the other change types is kept.
+func ChangeTypePrioritiesWithOrder(types []ChangeType) map[ChangeType]int {
+
+	priorities := make(map[ChangeType]int)
+	maxPriority := 0
+
+	for changeType, priority := range ChangeTypePriorities {
+		priorities[changeType] = priority
+		maxPriority = max(maxPriority, priority)
+	}
+
+	for i, changeType := range types {
+		priorities[changeType] = maxPriority + len(types) - i
+	}
+
+	return priorities
+}
@@ -1,7 +1,6 @@
 package conventional_commits
 
 import (
-	"github.com/pkg/errors"
 	"github.com/psanetra/git-semver/regex_utils"
 	"regexp"
 	"strings"
@@ -25,13 +24,18 @@ type ConventionalCommitMessage struct {
 	Footers                map[string][]string `json:"footers,omitempty"`
 }
 
-// inspired by https://www.conventionalcommits.org
+// Parses a commit message leniently. Returns a *ParseError if the message could not be parsed.
 func ParseCommitMessage(message string) (*ConventionalCommitMessage, error) {
+	return ParseCommitMessageWithMode(message, LENIENT)
+}
+
+// inspired by https://www.conventionalcommits.org
+func parseLenient(message string) (*ConventionalCommitMessage, error) {
 
 	match := regex_utils.SubmatchMap(messageRegex, message)
 
 	if match == nil {
-		return nil, errors.New("Could not parse commit message \"" + message + "\"")
+		return nil, lenientParseError(message)
 	}
 
 	breakingChangeIndicator := match["BCIndicator"]
@@ -83,6 +87,18 @@ func ParseCommitMessage(message string) (*ConventionalCommitMessage, error) {
 	return commitMessage, nil
 }
 
+// Returns the header error of the strict parser or a generic error if the strict parser accepts the header.
+func lenientParseError(message string) error {
+
+	header := strings.SplitN(message, "\n", 2)[0]
+
+	if _, err := parseHeader(strings.TrimRight(header, " \t\r")); err != nil {
+		return err
+	}
+
+	return &ParseError{Header: header, Line: 1, Column: 1, Rule: RULE_HEADER_TYPE, Message: "expected a conventional commit header"}
+}
+
 func trimWhitespace(str string) string {
 	return strings.Trim(str, " \t\r\n")
 }
@@ -131,7 +147,13 @@ func (c *ConventionalCommitMessage) footerHasBreakingChange() bool {
 	return false
 }
 
-func (c *ConventionalCommitMessage) breakingChangeDescriptions() []string {
+// Returns true if token is a BREAKING CHANGE footer token (e.g. "BREAKING CHANGE" or "BREAKING-CHANGE").
+func IsBreakingChangeToken(token string) bool {
+	return breakingChangeRegex.MatchString(token)
+}
+
+// Returns the values of all BREAKING CHANGE footers.
+func (c *ConventionalCommitMessage) BreakingChangeDescriptions() []string {
 	var ret []string
 
 	for key, value := range c.Footers {
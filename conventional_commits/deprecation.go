@@ -0,0 +1,37 @@
+package conventional_commits
+
+import (
+	"regexp"
+	"sort"
+)
+
+// deprecationRegex matches the footer tokens of deprecation notices (e.g. "DEPRECATED", "Deprecated" or "Deprecation")
+var deprecationRegex = regexp.MustCompile(`(?i)^deprecat(ed|ions?)$`)
+
+// Returns true if token is a footer token of deprecation notices (e.g. "DEPRECATED" or "Deprecation").
+func IsDeprecationToken(token string) bool {
+	return deprecationRegex.MatchString(token)
+}
+
+// Returns the values of the deprecation footers (e.g. "DEPRECATED: Use --out instead of --output") ordered by their
+// tokens and by their occurrence.
+func (c *ConventionalCommitMessage) DeprecationDescriptions() []string {
+
+	var tokens []string
+
+	for token := range c.Footers {
+		if IsDeprecationToken(token) {
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.Strings(tokens)
+
+	var ret []string
+
+	for _, token := range tokens {
+		ret = append(ret, c.Footers[token]...)
+	}
+
+	return ret
+}
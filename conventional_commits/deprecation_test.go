@@ -0,0 +1,16 @@
+package conventional_commits
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDeprecationDescriptions_should_return_values_of_deprecation_footers(t *testing.T) {
+
+	commitMessage, err := ParseCommitMessage("feat(cli): Add --out flag\n\nDEPRECATED: Use --out instead of --output.\nDeprecation: The short flag -o is removed in 3.0.\nRefs: #12")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Use --out instead of --output.", "The short flag -o is removed in 3.0."}, commitMessage.DeprecationDescriptions())
+	assert.True(t, IsDeprecationToken("deprecated"))
+	assert.False(t, IsDeprecationToken("Deprecates"))
+}
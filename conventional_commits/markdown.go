@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// Renders the breaking changes, features and bug fixes of the commit messages in markdown.
+//
+// Deprecated: Use release_notes.ToMarkdown, which renders the default template of the release notes.
 func ToMarkdown(messages []*ConventionalCommitMessage) string {
 
 	var markDownParts []string
@@ -40,7 +43,7 @@ func markdownBreakingChanges(commitsContainingBreakingChanges ByChangeTypeDesc)
 
 	for _, change := range commitsContainingBreakingChanges {
 
-		breakingChangeDescriptions := change.breakingChangeDescriptions()
+		breakingChangeDescriptions := change.BreakingChangeDescriptions()
 
 		if len(breakingChangeDescriptions) == 0 {
 			ret += "* "
@@ -78,7 +81,7 @@ func markdownSimpleChanges(changes []*ConventionalCommitMessage) string {
 
 	for _, change := range changes {
 		// skip breaking changes without separate description, because they are listed in another section
-		if change.ContainsBreakingChange && len(change.breakingChangeDescriptions()) == 0 {
+		if change.ContainsBreakingChange && len(change.BreakingChangeDescriptions()) == 0 {
 			continue
 		}
 
@@ -0,0 +1,50 @@
+package conventional_commits
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Rule identifies the rule of the Conventional Commits specification, which is violated by a commit message
+type Rule string
+
+const (
+	// RULE_HEADER_TYPE requires a type at the beginning of the header (e.g. "feat")
+	RULE_HEADER_TYPE Rule = "header-type"
+	// RULE_HEADER_SCOPE requires a non-empty scope in parentheses
+	RULE_HEADER_SCOPE Rule = "header-scope"
+	// RULE_HEADER_SEPARATOR requires ": " after the type, the scope and the breaking change indicator
+	RULE_HEADER_SEPARATOR Rule = "header-separator"
+	// RULE_HEADER_DESCRIPTION requires a description immediately following ": "
+	RULE_HEADER_DESCRIPTION Rule = "header-description"
+	// RULE_BODY_LEADING_BLANK requires an empty line between the header and the body
+	RULE_BODY_LEADING_BLANK Rule = "body-leading-blank"
+	// RULE_BREAKING_CHANGE_CASE requires the BREAKING CHANGE footer token to be uppercase
+	RULE_BREAKING_CHANGE_CASE Rule = "breaking-change-case"
+)
+
+// ParseError describes why a commit message is no conventional commit message
+type ParseError struct {
+	// Header is the first line of the commit message
+	Header string `json:"header"`
+	// Line and Column (in characters) of the violation. Both start at 1.
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("Could not parse commit message \"%s\": line %d, column %d: %s (%s)", e.Header, e.Line, e.Column, e.Message, e.Rule)
+}
+
+// Returns an error at the byte index of text. Indices after the end of text are counted as characters.
+func newParseError(header string, line int, text string, index int, rule Rule, message string) *ParseError {
+	return &ParseError{
+		Header:  header,
+		Line:    line,
+		Column:  utf8.RuneCountInString(text[:min(index, len(text))]) + max(index-len(text), 0) + 1,
+		Rule:    rule,
+		Message: message,
+	}
+}
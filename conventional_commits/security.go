@@ -0,0 +1,56 @@
+package conventional_commits
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// advisoryRegex matches CVE IDs (e.g. "CVE-2024-12345") and GitHub Security Advisory IDs (e.g. "GHSA-8r8j-xvfj-36f9")
+var advisoryRegex = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|GHSA(-[0-9a-z]{4}){3})\b`)
+
+// Returns the distinct CVE and GHSA IDs referenced in the footers (e.g. "Refs: CVE-2024-12345"). CVE IDs are
+// uppercase, GHSA IDs have an uppercase prefix and a lowercase suffix. The IDs are ordered by the footer tokens and by
+// their occurrence.
+func (c *ConventionalCommitMessage) Advisories() []string {
+
+	tokens := make([]string, 0, len(c.Footers))
+
+	for token := range c.Footers {
+		tokens = append(tokens, token)
+	}
+
+	sort.Strings(tokens)
+
+	var ret []string
+	seen := make(map[string]bool)
+
+	for _, token := range tokens {
+		for _, value := range c.Footers[token] {
+			for _, id := range advisoryRegex.FindAllString(value, -1) {
+				id = normalizeAdvisory(id)
+
+				if !seen[id] {
+					seen[id] = true
+					ret = append(ret, id)
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+// Returns true if the commit has the type SECURITY or references advisories in its footers.
+func (c *ConventionalCommitMessage) IsSecurityFix() bool {
+	return c.ChangeType == SECURITY || len(c.Advisories()) > 0
+}
+
+func normalizeAdvisory(id string) string {
+
+	if strings.HasPrefix(strings.ToUpper(id), "GHSA-") {
+		return "GHSA-" + strings.ToLower(id[len("GHSA-"):])
+	}
+
+	return strings.ToUpper(id)
+}
@@ -0,0 +1,29 @@
+package conventional_commits
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdvisories_should_return_distinct_ids_of_footers(t *testing.T) {
+
+	commitMessage, err := ParseCommitMessage("fix(deps): Bump yaml\n\nFixes cve-2024-12345 in the description.\n\nRefs: CVE-2024-12345, GHSA-8R8J-xvfj-36f9\nSee-Also: #12\nAdvisory: CVE-2023-0001")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"CVE-2023-0001", "CVE-2024-12345", "GHSA-8r8j-xvfj-36f9"}, commitMessage.Advisories())
+	assert.True(t, commitMessage.IsSecurityFix())
+}
+
+func TestIsSecurityFix_should_detect_security_type(t *testing.T) {
+
+	security, err := ParseCommitMessage("security: Escape input")
+
+	assert.Nil(t, err)
+	assert.True(t, security.IsSecurityFix())
+	assert.Empty(t, security.Advisories())
+
+	fix, err := ParseCommitMessage("fix: Mention CVE-2024-12345 only in the description")
+
+	assert.Nil(t, err)
+	assert.False(t, fix.IsSecurityFix())
+}
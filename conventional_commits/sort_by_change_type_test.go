@@ -21,3 +21,16 @@ func TestSort_ByChangeTypeDesc(t *testing.T) {
 	assert.Equal(t, PERF, messages[2].ChangeType)
 
 }
+
+func TestChangeTypePrioritiesWithOrder(t *testing.T) {
+
+	priorities := ChangeTypePrioritiesWithOrder([]ChangeType{PERF, "revert", FEATURE})
+
+	assert.Greater(t, priorities[PERF], priorities["revert"])
+	assert.Greater(t, priorities["revert"], priorities[FEATURE])
+	assert.Greater(t, priorities[FEATURE], priorities[FIX])
+	assert.Greater(t, priorities[FIX], priorities[DOCS])
+
+	assert.Greater(t, ChangeTypePriorities[FEATURE], ChangeTypePriorities[PERF])
+	assert.NotContains(t, ChangeTypePriorities, ChangeType("revert"))
+}
@@ -0,0 +1,207 @@
+package conventional_commits
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
+)
+
+// Mode selects the parser of ParseCommitMessageWithMode
+type Mode string
+
+const (
+	// LENIENT accepts common deviations from the specification (e.g. whitespace after ": " or "BREAKING CHANGES"
+	// footers)
+	LENIENT Mode = "lenient"
+	// STRICT only accepts commit messages conforming to Conventional Commits 1.0.0
+	STRICT Mode = "strict"
+)
+
+// strictFooterTokenRegex matches footer tokens of the specification. BREAKING-CHANGE matches the word token.
+var strictFooterTokenRegex = regexp.MustCompile(`^(?P<Token>BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9\-]*)(: | #)`)
+
+// breakingChangeTokenRegex matches BREAKING CHANGE tokens in any case
+var breakingChangeTokenRegex = regexp.MustCompile(`^(?i)breaking[ \-]change(: | #)`)
+
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case LENIENT, STRICT:
+		return Mode(mode), nil
+	}
+
+	return "", errors.Errorf("Unknown parser mode \"%s\" (expected %s or %s)", mode, LENIENT, STRICT)
+}
+
+// Parses the commit message with the parser of the mode.
+func ParseCommitMessageWithMode(message string, mode Mode) (*ConventionalCommitMessage, error) {
+	if mode == STRICT {
+		return ParseStrict(message)
+	}
+
+	return parseLenient(message)
+}
+
+// Parses a commit message according to https://www.conventionalcommits.org/en/v1.0.0/. CRLF line endings and trailing
+// whitespace are ignored. The footers are the final paragraph if it starts with a footer token. Footer values may span
+// multiple lines until the next footer token and keep the "#" of the "<token> #<value>" separator. Types and footer tokens
+// are case-insensitive except BREAKING CHANGE, which must be uppercase. BREAKING-CHANGE is a synonym of BREAKING
+// CHANGE. Returns a *ParseError if the message does not conform to the specification.
+func ParseStrict(message string) (*ConventionalCommitMessage, error) {
+
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	for len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	commitMessage, err := parseHeader(lines[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) > 1 && lines[1] != "" {
+		return nil, newParseError(lines[0], 2, lines[1], 0, RULE_BODY_LEADING_BLANK, "expected an empty line after the header")
+	}
+
+	var rest []string
+
+	if len(lines) > 2 {
+		rest = lines[2:]
+	}
+
+	// only the final paragraph may contain the footers
+	footersStart := len(rest)
+	lastParagraphStart := 0
+
+	for i := len(rest) - 1; i > 0; i-- {
+		if rest[i-1] == "" {
+			lastParagraphStart = i
+			break
+		}
+	}
+
+	if lastParagraphStart < len(rest) && strictFooterTokenRegex.MatchString(rest[lastParagraphStart]) {
+		footersStart = lastParagraphStart
+	}
+
+	// body paragraphs may start with "Breaking change:", but the final paragraph must not contain malformed footers
+	for i := lastParagraphStart; i < len(rest); i++ {
+		if (i == lastParagraphStart || i > footersStart) && isLowerCaseBreakingChangeToken(rest[i]) {
+			return nil, newParseError(lines[0], i+3, rest[i], 0, RULE_BREAKING_CHANGE_CASE, "BREAKING CHANGE must be uppercase")
+		}
+	}
+
+	commitMessage.Body = trimWhitespace(strings.Join(rest[:footersStart], "\n"))
+
+	var token string
+	var value []string
+
+	addFooter := func() {
+		if token != "" {
+			commitMessage.Footers[token] = append(commitMessage.Footers[token], trimWhitespace(strings.Join(value, "\n")))
+		}
+	}
+
+	for _, line := range rest[footersStart:] {
+		match := strictFooterTokenRegex.FindStringSubmatchIndex(line)
+
+		if match == nil {
+			value = append(value, line)
+			continue
+		}
+
+		addFooter()
+		token = line[match[2]:match[3]]
+		valueStart := match[1]
+
+		if line[match[4]:match[5]] == " #" {
+			// the "#" belongs to the value (e.g. "Refs #123" references "#123")
+			valueStart--
+		}
+
+		value = []string{line[valueStart:]}
+	}
+
+	addFooter()
+
+	commitMessage.ContainsBreakingChange = commitMessage.ContainsBreakingChange || commitMessage.footerHasBreakingChange()
+
+	return commitMessage, nil
+}
+
+// Parses "<type>[(<scope>)][!]: <description>".
+func parseHeader(header string) (*ConventionalCommitMessage, error) {
+
+	i := 0
+
+	for i < len(header) && isLetter(header[i]) {
+		i++
+	}
+
+	if i == 0 {
+		return nil, newParseError(header, 1, header, 0, RULE_HEADER_TYPE, "expected a type (e.g. \"feat\" or \"fix\")")
+	}
+
+	commitMessage := &ConventionalCommitMessage{
+		ChangeType: ChangeType(strings.ToLower(header[:i])),
+		Footers:    make(map[string][]string),
+	}
+
+	if i < len(header) && header[i] == '(' {
+		end := strings.IndexByte(header[i:], ')')
+
+		if end < 0 {
+			return nil, newParseError(header, 1, header, i, RULE_HEADER_SCOPE, "expected \")\" after the scope")
+		}
+
+		commitMessage.Scope = header[i+1 : i+end]
+
+		if strings.TrimSpace(commitMessage.Scope) == "" {
+			return nil, newParseError(header, 1, header, i+1, RULE_HEADER_SCOPE, "expected a scope in parentheses")
+		}
+
+		i += end + 1
+	}
+
+	if i < len(header) && header[i] == '!' {
+		commitMessage.ContainsBreakingChange = true
+		i++
+	}
+
+	if header[i:] == ":" {
+		// trailing whitespace was removed
+		return nil, newParseError(header, 1, header, i+2, RULE_HEADER_DESCRIPTION, "expected a description")
+	}
+
+	if !strings.HasPrefix(header[i:], ": ") {
+		return nil, newParseError(header, 1, header, i, RULE_HEADER_SEPARATOR, "expected \": \" after the type, scope and breaking change indicator")
+	}
+
+	i += 2
+
+	if i >= len(header) {
+		return nil, newParseError(header, 1, header, i, RULE_HEADER_DESCRIPTION, "expected a description")
+	}
+
+	if header[i] == ' ' || header[i] == '\t' {
+		return nil, newParseError(header, 1, header, i, RULE_HEADER_DESCRIPTION, "expected the description immediately after \": \"")
+	}
+
+	commitMessage.Description = header[i:]
+
+	return commitMessage, nil
+}
+
+func isLowerCaseBreakingChangeToken(line string) bool {
+	return breakingChangeTokenRegex.MatchString(line) &&
+		!strings.HasPrefix(line, "BREAKING CHANGE") && !strings.HasPrefix(line, "BREAKING-CHANGE")
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
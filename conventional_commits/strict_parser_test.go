@@ -0,0 +1,134 @@
+package conventional_commits
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseStrict_should_parse_body_and_multiline_footers(t *testing.T) {
+
+	commitMessage, err := ParseStrict("Feat(api)!: Add endpoint  \r\n\r\nFirst paragraph\r\n\r\nSecond paragraph \r\n\r\nReviewed-by: Z\r\nRefs #123\r\nBREAKING-CHANGE: Removed the old endpoint.\r\n  Use the new one.\r\n")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ConventionalCommitMessage{
+		ChangeType:             "feat",
+		Scope:                  "api",
+		ContainsBreakingChange: true,
+		Description:            "Add endpoint",
+		Body:                   "First paragraph\n\nSecond paragraph",
+		Footers: map[string][]string{
+			"Reviewed-by":     {"Z"},
+			"Refs":            {"#123"},
+			"BREAKING-CHANGE": {"Removed the old endpoint.\n  Use the new one."},
+		},
+	}, commitMessage)
+}
+
+func TestParseStrict_should_detect_breaking_change_footer(t *testing.T) {
+
+	commitMessage, err := ParseStrict("fix: Fix\n\nBREAKING CHANGE: Changed behaviour")
+
+	assert.Nil(t, err)
+	assert.True(t, commitMessage.ContainsBreakingChange)
+	assert.Equal(t, []string{"Changed behaviour"}, commitMessage.BreakingChangeDescriptions())
+}
+
+func TestParseStrict_should_only_parse_footers_in_final_paragraph(t *testing.T) {
+
+	commitMessage, err := ParseStrict("fix: Fix\n\nBREAKING CHANGE: Not a footer\n\nRefs #12\n\nSee the issue.")
+
+	assert.Nil(t, err)
+	assert.False(t, commitMessage.ContainsBreakingChange)
+	assert.Equal(t, "BREAKING CHANGE: Not a footer\n\nRefs #12\n\nSee the issue.", commitMessage.Body)
+	assert.Empty(t, commitMessage.Footers)
+
+	commitMessage, err = ParseStrict("fix: Fix\n\nReviewed-by: Z\n\nRefs #12")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Reviewed-by: Z", commitMessage.Body)
+	assert.Equal(t, map[string][]string{"Refs": {"#12"}}, commitMessage.Footers)
+}
+
+func TestParseStrict_should_accept_lowercase_breaking_change_in_body_paragraphs(t *testing.T) {
+
+	commitMessage, err := ParseStrict("fix: Fix\n\nBreaking change: none, the old behaviour was a bug.\n\nRefs #12")
+
+	assert.Nil(t, err)
+	assert.False(t, commitMessage.ContainsBreakingChange)
+	assert.Equal(t, "Breaking change: none, the old behaviour was a bug.", commitMessage.Body)
+	assert.Equal(t, map[string][]string{"Refs": {"#12"}}, commitMessage.Footers)
+}
+
+func TestParseStrict_should_treat_invalid_footer_tokens_as_body(t *testing.T) {
+
+	commitMessage, err := ParseStrict("fix: Fix\n\nBREAKING CHANGES: Not a footer\n\nSee also: text")
+
+	assert.Nil(t, err)
+	assert.False(t, commitMessage.ContainsBreakingChange)
+	assert.Equal(t, "BREAKING CHANGES: Not a footer\n\nSee also: text", commitMessage.Body)
+	assert.Empty(t, commitMessage.Footers)
+}
+
+func TestParseStrict_should_return_positioned_errors(t *testing.T) {
+
+	for _, testCase := range []struct {
+		message string
+		line    int
+		column  int
+		rule    Rule
+	}{
+		{"", 1, 1, RULE_HEADER_TYPE},
+		{": description", 1, 1, RULE_HEADER_TYPE},
+		{"feat(api: description", 1, 5, RULE_HEADER_SCOPE},
+		{"feat( ): description", 1, 6, RULE_HEADER_SCOPE},
+		{"feat description", 1, 5, RULE_HEADER_SEPARATOR},
+		{"feat(äpi):description", 1, 10, RULE_HEADER_SEPARATOR},
+		{"feat!:  description", 1, 8, RULE_HEADER_DESCRIPTION},
+		{"feat: ", 1, 7, RULE_HEADER_DESCRIPTION},
+		{"feat: description\nbody", 2, 1, RULE_BODY_LEADING_BLANK},
+		{"feat: description\n\nbody\n\nbreaking change: text", 5, 1, RULE_BREAKING_CHANGE_CASE},
+		{"feat: description\n\nRefs: #1\nBreaking-Change: text", 4, 1, RULE_BREAKING_CHANGE_CASE},
+	} {
+		_, err := ParseStrict(testCase.message)
+
+		parseError, ok := err.(*ParseError)
+
+		if assert.True(t, ok, testCase.message) {
+			assert.Equal(t, testCase.line, parseError.Line, testCase.message)
+			assert.Equal(t, testCase.column, parseError.Column, testCase.message)
+			assert.Equal(t, testCase.rule, parseError.Rule, testCase.message)
+		}
+	}
+}
+
+func TestParseError_should_describe_position_and_rule(t *testing.T) {
+
+	_, err := ParseStrict("feat description")
+
+	assert.EqualError(t, err, "Could not parse commit message \"feat description\": line 1, column 5: expected \": \" after the type, scope and breaking change indicator (header-separator)")
+}
+
+func TestParseCommitMessage_should_return_positioned_errors_in_lenient_mode(t *testing.T) {
+
+	_, err := ParseCommitMessage("feat(api description")
+
+	assert.Equal(t, &ParseError{
+		Header:  "feat(api description",
+		Line:    1,
+		Column:  5,
+		Rule:    RULE_HEADER_SCOPE,
+		Message: "expected \")\" after the scope",
+	}, err)
+}
+
+func TestParseCommitMessageWithMode_should_select_parser(t *testing.T) {
+
+	_, err := ParseCommitMessageWithMode("feat:  description", LENIENT)
+	assert.Nil(t, err)
+
+	_, err = ParseCommitMessageWithMode("feat:  description", STRICT)
+	assert.NotNil(t, err)
+
+	_, err = ParseMode("pedantic")
+	assert.EqualError(t, err, "Unknown parser mode \"pedantic\" (expected lenient or strict)")
+}
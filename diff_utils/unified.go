@@ -0,0 +1,121 @@
+package diff_utils
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"strings"
+)
+
+const contextLines = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Returns a unified diff (like "git diff") of the old and new content of a file. Returns an empty string if both are equal.
+func Unified(fileName string, oldContent string, newContent string) string {
+
+	if oldContent == newContent {
+		return ""
+	}
+
+	var lines []diffLine
+
+	for _, d := range diff.Do(oldContent, newContent) {
+		op := byte(' ')
+
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+
+		for _, text := range splitLines(d.Text) {
+			lines = append(lines, diffLine{op: op, text: text})
+		}
+	}
+
+	// line numbers of each diff line in the old and new content
+	oldLineNumbers := make([]int, len(lines))
+	newLineNumbers := make([]int, len(lines))
+	var changes []int
+
+	oldLineNumber, newLineNumber := 1, 1
+
+	for i, line := range lines {
+		oldLineNumbers[i] = oldLineNumber
+		newLineNumbers[i] = newLineNumber
+
+		if line.op != '+' {
+			oldLineNumber++
+		}
+
+		if line.op != '-' {
+			newLineNumber++
+		}
+
+		if line.op != ' ' {
+			changes = append(changes, i)
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("--- a/" + fileName + "\n")
+	sb.WriteString("+++ b/" + fileName + "\n")
+
+	for i := 0; i < len(changes); {
+		j := i
+
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*contextLines+1 {
+			j++
+		}
+
+		start := max(changes[i]-contextLines, 0)
+		end := min(changes[j]+contextLines, len(lines)-1)
+
+		oldCount, newCount := 0, 0
+
+		for _, line := range lines[start : end+1] {
+			if line.op != '+' {
+				oldCount++
+			}
+
+			if line.op != '-' {
+				newCount++
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(oldLineNumbers[start], oldCount), hunkRange(newLineNumbers[start], newCount)))
+
+		for _, line := range lines[start : end+1] {
+			sb.WriteByte(line.op)
+			sb.WriteString(line.text + "\n")
+		}
+
+		i = j + 1
+	}
+
+	return sb.String()
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		start--
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
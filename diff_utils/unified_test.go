@@ -0,0 +1,44 @@
+package diff_utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnified_should_return_empty_string_if_content_is_equal(t *testing.T) {
+	assert.Equal(t, "", Unified("file.txt", "a\nb\n", "a\nb\n"))
+}
+
+func TestUnified_should_render_new_file(t *testing.T) {
+	assert.Equal(
+		t,
+		`--- a/file.txt
++++ b/file.txt
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		Unified("file.txt", "", "a\nb\n"),
+	)
+}
+
+func TestUnified_should_render_separate_hunks_with_context(t *testing.T) {
+	assert.Equal(
+		t,
+		`--- a/file.txt
++++ b/file.txt
+@@ -1,3 +1,4 @@
++new
+ 1
+ 2
+ 3
+@@ -7,4 +8,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`,
+		Unified("file.txt", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "new\n1\n2\n3\n4\n5\n6\n7\n8\n9\nten\n"),
+	)
+}
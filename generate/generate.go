@@ -0,0 +1,331 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/latest"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var buildMetadataRegex = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
+// Data is passed to the templates
+type Data struct {
+	// Version contains the build metadata if there is any
+	Version            string
+	Major              int
+	Minor              int
+	Patch              int
+	PreRelease         string
+	PreReleaseParts    []string
+	BuildMetadata      string
+	BuildMetadataParts []string
+	TagName            string
+	Commit             string
+	ShortCommit        string
+	CommitDate         time.Time
+	// Vars are configured per file
+	Vars map[string]string
+}
+
+type GenerateOptions struct {
+	// NextOptions are used to calculate the version unless Latest is set
+	next.NextOptions
+	// Latest uses the latest version and the commit of its tag instead of the next version and HEAD
+	Latest             bool
+	IncludePreReleases bool
+	// Prefix is prepended to the next version to build the tag name (e.g. "v")
+	Prefix        string
+	BuildMetadata string
+	Files         []config.GenerateFile
+	// DryRun only renders the files without writing them
+	DryRun bool
+}
+
+type FileResult struct {
+	// Path is relative to the root of the repository
+	Path            string
+	PreviousContent string
+	Content         string
+	// commitLines contains the indexes of the lines of Content, which are derived from the commit
+	commitLines map[int]bool
+}
+
+func (r *FileResult) Changed() bool {
+	return r.PreviousContent != r.Content
+}
+
+// Returns true if the content differs from the previous content in other lines than the lines derived from the commit
+// (.Commit, .ShortCommit and .CommitDate). Files generated for the next version are committed before the version is
+// tagged, so these lines can not be up to date with the commit of the tag.
+func (r *FileResult) VersionChanged() bool {
+
+	if !r.Changed() || len(r.commitLines) == 0 {
+		return r.Changed()
+	}
+
+	previousLines := strings.Split(r.PreviousContent, "\n")
+	lines := strings.Split(r.Content, "\n")
+
+	if len(previousLines) != len(lines) {
+		return true
+	}
+
+	for i := range lines {
+		if !r.commitLines[i] && lines[i] != previousLines[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Renders all files with the next (or latest) version and writes them unless options.DryRun is set.
+func Generate(options GenerateOptions) (*Data, []*FileResult, error) {
+
+	if options.BuildMetadata != "" && !buildMetadataRegex.MatchString(options.BuildMetadata) {
+		return nil, nil, errors.Errorf("Invalid build metadata %s", options.BuildMetadata)
+	}
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	worktree, err := repo.Worktree()
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not open worktree")
+	}
+
+	var version *semver.Version
+	var tagName string
+	var commitHash plumbing.Hash
+
+	if options.Latest {
+		var tagRef *plumbing.Reference
+
+		version, tagRef, err = latest.FindLatestVersion(repo, options.MajorVersionFilter, options.IncludePreReleases)
+
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "Error while trying to find latest version tag")
+		}
+
+		if tagRef == nil {
+			return nil, nil, errors.New("Could not find a version tag")
+		}
+
+		tagName = tagRef.Name().Short()
+		commitHash = git_utils.RefToCommitHash(repo.Storer, tagRef)
+	} else {
+		version, err = next.Next(options.NextOptions)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		head, err := repo.Head()
+
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "Could not find HEAD")
+		}
+
+		tagName = options.Prefix + version.ToString()
+		commitHash = head.Hash()
+	}
+
+	commit, err := repo.CommitObject(commitHash)
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not find commit "+commitHash.String())
+	}
+
+	data := NewData(version, options.BuildMetadata, tagName, commit)
+
+	root := worktree.Filesystem.Root()
+
+	results, err := Render(root, options.Files, data)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if options.DryRun {
+		return data, results, nil
+	}
+
+	return data, results, Write(root, results)
+}
+
+func NewData(version *semver.Version, buildMetadata string, tagName string, commit *object.Commit) *Data {
+
+	data := &Data{
+		Version:       version.ToString(),
+		Major:         version.Major,
+		Minor:         version.Minor,
+		Patch:         version.Patch,
+		BuildMetadata: buildMetadata,
+		TagName:       tagName,
+	}
+
+	for _, part := range version.PreReleaseTag {
+		data.PreReleaseParts = append(data.PreReleaseParts, fmt.Sprintf("%v", part))
+	}
+
+	data.PreRelease = strings.Join(data.PreReleaseParts, ".")
+
+	if buildMetadata != "" {
+		data.Version += "+" + buildMetadata
+		data.BuildMetadataParts = strings.Split(buildMetadata, ".")
+	}
+
+	if commit != nil {
+		data.Commit = commit.Hash.String()
+		data.ShortCommit = data.Commit[:7]
+		data.CommitDate = commit.Committer.When
+	}
+
+	return data
+}
+
+var templateFuncs = template.FuncMap{
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"replace": strings.ReplaceAll,
+	"join":    strings.Join,
+	"rfc3339": func(t time.Time) string {
+		return t.Format(time.RFC3339)
+	},
+}
+
+// Renders the templates of all files with data, but does not write them.
+func Render(root string, files []config.GenerateFile, data *Data) ([]*FileResult, error) {
+
+	var results []*FileResult
+
+	for _, file := range files {
+
+		tmpl, err := loadTemplate(root, file.Template)
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not load template for "+file.Path)
+		}
+
+		fileData := *data
+		fileData.Vars = file.Vars
+
+		var buffer bytes.Buffer
+
+		if err = tmpl.Execute(&buffer, &fileData); err != nil {
+			return nil, errors.WithMessage(err, "Could not render "+file.Path)
+		}
+
+		// the lines, which change without commit, are derived from the commit
+		withoutCommit := fileData
+		withoutCommit.Commit = ""
+		withoutCommit.ShortCommit = ""
+		withoutCommit.CommitDate = time.Time{}
+
+		var bufferWithoutCommit bytes.Buffer
+
+		if err = tmpl.Execute(&bufferWithoutCommit, &withoutCommit); err != nil {
+			return nil, errors.WithMessage(err, "Could not render "+file.Path)
+		}
+
+		previousContent, err := os.ReadFile(filepath.Join(root, file.Path))
+
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.WithMessage(err, "Could not read "+file.Path)
+		}
+
+		results = append(results, &FileResult{
+			Path:            file.Path,
+			PreviousContent: string(previousContent),
+			Content:         buffer.String(),
+			commitLines:     differentLines(buffer.String(), bufferWithoutCommit.String()),
+		})
+	}
+
+	return results, nil
+}
+
+// Returns the indexes of the lines, which differ. Returns nil if the number of lines differs, as the lines can not be
+// matched then.
+func differentLines(a string, b string) map[int]bool {
+
+	linesA := strings.Split(a, "\n")
+	linesB := strings.Split(b, "\n")
+
+	if len(linesA) != len(linesB) {
+		return nil
+	}
+
+	ret := make(map[int]bool)
+
+	for i := range linesA {
+		if linesA[i] != linesB[i] {
+			ret[i] = true
+		}
+	}
+
+	return ret
+}
+
+// Writes all changed files.
+func Write(root string, results []*FileResult) error {
+
+	for _, result := range results {
+		if !result.Changed() {
+			continue
+		}
+
+		path := filepath.Join(root, result.Path)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return errors.WithMessage(err, "Could not create directory for "+result.Path)
+		}
+
+		if err := os.WriteFile(path, []byte(result.Content), 0666); err != nil {
+			return errors.WithMessage(err, "Could not write "+result.Path)
+		}
+	}
+
+	return nil
+}
+
+func loadTemplate(root string, name string) (*template.Template, error) {
+
+	if name == "" {
+		return nil, errors.New("No template specified")
+	}
+
+	text, ok := builtInTemplates[name]
+
+	if !ok {
+		content, err := os.ReadFile(filepath.Join(root, name))
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not read template file")
+		}
+
+		text = string(content)
+	}
+
+	return template.New(name).Funcs(templateFuncs).Parse(text)
+}
@@ -0,0 +1,200 @@
+package generate
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var commit = &object.Commit{
+	Hash: plumbing.NewHash("0123456789abcdef0123456789abcdef01234567"),
+	Committer: object.Signature{
+		When: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func renderSingleFile(t *testing.T, file config.GenerateFile) string {
+	version, err := semver.ParseVersion("1.4.0-rc.1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Render(t.TempDir(), []config.GenerateFile{file}, NewData(version, "build.5", "v1.4.0-rc.1", commit))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return results[0].Content
+}
+
+func TestRender_should_render_go_template(t *testing.T) {
+	content := renderSingleFile(t, config.GenerateFile{Path: "version/version.go", Template: GO, Vars: map[string]string{"package": "buildinfo"}})
+
+	assert.Equal(t, `// Code generated by git-semver. DO NOT EDIT.
+
+package buildinfo
+
+const (
+	Version       = "1.4.0-rc.1+build.5"
+	Major         = 1
+	Minor         = 4
+	Patch         = 0
+	PreRelease    = "rc.1"
+	BuildMetadata = "build.5"
+	Tag           = "v1.4.0-rc.1"
+	Commit        = "0123456789abcdef0123456789abcdef01234567"
+	CommitDate    = "2026-10-18T12:00:00Z"
+)
+`, content)
+}
+
+func TestRender_should_render_c_template(t *testing.T) {
+	content := renderSingleFile(t, config.GenerateFile{Path: "version.h", Template: C, Vars: map[string]string{"prefix": "APP_VERSION"}})
+
+	assert.Contains(t, content, "#ifndef APP_VERSION_H\n#define APP_VERSION_H\n")
+	assert.Contains(t, content, "#define APP_VERSION \"1.4.0-rc.1+build.5\"\n#define APP_VERSION_MAJOR 1\n")
+}
+
+func TestRender_should_render_properties_template(t *testing.T) {
+	content := renderSingleFile(t, config.GenerateFile{Path: "version.properties", Template: PROPERTIES})
+
+	assert.Contains(t, content, "version=1.4.0-rc.1+build.5\nversion.major=1\n")
+	assert.Contains(t, content, "version.commitDate=2026-10-18T12:00:00Z\n")
+}
+
+func TestRender_should_render_typescript_template(t *testing.T) {
+	content := renderSingleFile(t, config.GenerateFile{Path: "src/version.ts", Template: TYPESCRIPT})
+
+	assert.Contains(t, content, `export const PRE_RELEASE: readonly string[] = ["rc", "1"];`)
+	assert.Contains(t, content, `export const BUILD_METADATA: readonly string[] = ["build", "5"];`)
+}
+
+func TestRender_should_render_template_file(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "version.tmpl"), []byte(`{{ .Major }}.{{ .Minor }} {{ .ShortCommit }} {{ .TagName | upper }}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Render(root, []config.GenerateFile{{Path: "version.txt", Template: "version.tmpl"}}, NewData(&semver.Version{Major: 1, Minor: 4}, "", "v1.4.0", commit))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.4 0123456 V1.4.0", results[0].Content)
+	assert.True(t, results[0].Changed())
+}
+
+func TestGenerate_should_write_files_with_next_version(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	hash := test_utils.Commit(t, repo, "fix: Add fix")
+
+	data, results, err := Generate(GenerateOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Files:       []config.GenerateFile{{Path: "gen/version.properties", Template: PROPERTIES}},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", data.Version)
+	assert.Equal(t, "v1.0.1", data.TagName)
+	assert.Equal(t, hash.String(), data.Commit)
+
+	content, err := os.ReadFile(filepath.Join(dir, "gen", "version.properties"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, results[0].Content, string(content))
+}
+
+func TestGenerate_should_use_commit_of_latest_tag(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "fix: Add fix")
+
+	data, _, err := Generate(GenerateOptions{
+		NextOptions: next.NextOptions{Workdir: dir, MajorVersionFilter: -1},
+		Latest:      true,
+		DryRun:      true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", data.Version)
+	assert.Equal(t, "v1.0.0", data.TagName)
+	assert.Equal(t, hash.String(), data.Commit)
+}
+
+func TestGenerate_should_be_up_to_date_after_committing_and_tagging_generated_files(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "fix: Add fix")
+
+	var files []config.GenerateFile
+
+	for _, template := range []string{GO, C, PROPERTIES, TYPESCRIPT} {
+		files = append(files, config.GenerateFile{Path: "gen/version." + template, Template: template})
+	}
+
+	_, results, err := Generate(GenerateOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Files:       files,
+	})
+
+	assert.Nil(t, err)
+
+	for _, result := range results {
+		test_utils.WriteFile(t, repo, result.Path, result.Content)
+	}
+
+	test_utils.Commit(t, repo, "chore(release): 1.0.1")
+	test_utils.Tag(t, repo, "v1.0.1")
+
+	// like generate --check
+	_, results, err = Generate(GenerateOptions{
+		NextOptions: next.NextOptions{Workdir: dir, MajorVersionFilter: -1},
+		Latest:      true,
+		Files:       files,
+		DryRun:      true,
+	})
+
+	assert.Nil(t, err)
+
+	for _, result := range results {
+		assert.True(t, result.Changed(), result.Path+" should contain the commit of the tag")
+		assert.False(t, result.VersionChanged(), result.Path+" should be up to date")
+	}
+}
+
+func TestFileResult_VersionChanged_should_ignore_lines_derived_from_the_commit(t *testing.T) {
+	version, err := semver.ParseVersion("1.0.1")
+	assert.Nil(t, err)
+
+	results, err := Render(t.TempDir(), []config.GenerateFile{{Path: "version.go", Template: GO}}, NewData(version, "", "v1.0.1", commit))
+	assert.Nil(t, err)
+
+	result := results[0]
+	result.PreviousContent = strings.ReplaceAll(result.Content, "0123456789abcdef0123456789abcdef01234567", "fedcba9876543210fedcba9876543210fedcba98")
+
+	assert.True(t, result.Changed())
+	assert.False(t, result.VersionChanged())
+
+	result.PreviousContent = strings.ReplaceAll(result.PreviousContent, "1.0.1", "1.0.0")
+
+	assert.True(t, result.VersionChanged())
+}
@@ -0,0 +1,70 @@
+package generate
+
+const (
+	GO         = "go"
+	C          = "c"
+	PROPERTIES = "properties"
+	TYPESCRIPT = "typescript"
+)
+
+var builtInTemplates = map[string]string{
+	GO: `// Code generated by git-semver. DO NOT EDIT.
+
+package {{ or .Vars.package "version" }}
+
+const (
+	Version       = "{{ .Version }}"
+	Major         = {{ .Major }}
+	Minor         = {{ .Minor }}
+	Patch         = {{ .Patch }}
+	PreRelease    = "{{ .PreRelease }}"
+	BuildMetadata = "{{ .BuildMetadata }}"
+	Tag           = "{{ .TagName }}"
+	Commit        = "{{ .Commit }}"
+	CommitDate    = "{{ .CommitDate | rfc3339 }}"
+)
+`,
+	C: `{{- $prefix := or .Vars.prefix "VERSION" -}}
+{{- $guard := or .Vars.guard (printf "%s_H" $prefix) -}}
+/* Generated by git-semver. Do not edit. */
+
+#ifndef {{ $guard }}
+#define {{ $guard }}
+
+#define {{ $prefix }} "{{ .Version }}"
+#define {{ $prefix }}_MAJOR {{ .Major }}
+#define {{ $prefix }}_MINOR {{ .Minor }}
+#define {{ $prefix }}_PATCH {{ .Patch }}
+#define {{ $prefix }}_PRE_RELEASE "{{ .PreRelease }}"
+#define {{ $prefix }}_BUILD_METADATA "{{ .BuildMetadata }}"
+#define {{ $prefix }}_TAG "{{ .TagName }}"
+#define {{ $prefix }}_COMMIT "{{ .Commit }}"
+#define {{ $prefix }}_COMMIT_DATE "{{ .CommitDate | rfc3339 }}"
+
+#endif
+`,
+	PROPERTIES: `{{- $prefix := or .Vars.prefix "version" -}}
+# Generated by git-semver. Do not edit.
+{{ $prefix }}={{ .Version }}
+{{ $prefix }}.major={{ .Major }}
+{{ $prefix }}.minor={{ .Minor }}
+{{ $prefix }}.patch={{ .Patch }}
+{{ $prefix }}.preRelease={{ .PreRelease }}
+{{ $prefix }}.buildMetadata={{ .BuildMetadata }}
+{{ $prefix }}.tag={{ .TagName }}
+{{ $prefix }}.commit={{ .Commit }}
+{{ $prefix }}.commitDate={{ .CommitDate | rfc3339 }}
+`,
+	TYPESCRIPT: `// Generated by git-semver. Do not edit.
+
+export const VERSION = "{{ .Version }}";
+export const MAJOR = {{ .Major }};
+export const MINOR = {{ .Minor }};
+export const PATCH = {{ .Patch }};
+export const PRE_RELEASE: readonly string[] = [{{ range $i, $part := .PreReleaseParts }}{{ if $i }}, {{ end }}"{{ $part }}"{{ end }}];
+export const BUILD_METADATA: readonly string[] = [{{ range $i, $part := .BuildMetadataParts }}{{ if $i }}, {{ end }}"{{ $part }}"{{ end }}];
+export const TAG = "{{ .TagName }}";
+export const COMMIT = "{{ .Commit }}";
+export const COMMIT_DATE = "{{ .CommitDate | rfc3339 }}";
+`,
+}
@@ -0,0 +1,122 @@
+package git_utils
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/pkg/errors"
+	"os"
+	"path"
+	"time"
+)
+
+// ErrRefLocked indicates that another process kept a reference locked while it should have been updated
+var ErrRefLocked = errors.New("Reference is locked by another process")
+
+// LOCK_ATTEMPTS is the number of attempts to lock a reference. The delay between attempts starts with
+// LOCK_RETRY_DELAY and is doubled after each attempt.
+const LOCK_ATTEMPTS = 5
+const LOCK_RETRY_DELAY = 20 * time.Millisecond
+
+// Creates a lightweight tag, but only if the tag does not exist yet. Returns git.ErrTagExists otherwise.
+// The reference is locked via a "<ref>.lock" file (like git itself does), so concurrent processes
+// can not both create the same tag. Returns ErrRefLocked if the lock could not be acquired.
+func CreateTagRef(repo *git.Repository, tagName string, hash plumbing.Hash) (*plumbing.Reference, error) {
+
+	refName := plumbing.NewTagReferenceName(tagName)
+
+	if err := refName.Validate(); err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockRef(repo, refName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	_, err = repo.Storer.Reference(refName)
+
+	if err == nil {
+		return nil, git.ErrTagExists
+	} else if err != plumbing.ErrReferenceNotFound {
+		return nil, err
+	}
+
+	ref := plumbing.NewHashReference(refName, hash)
+
+	if err = repo.Storer.SetReference(ref); err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+// Removes the tag, but only if it still points to the expected hash.
+func RemoveTagRef(repo *git.Repository, ref *plumbing.Reference) error {
+
+	unlock, err := lockRef(repo, ref.Name())
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	currentRef, err := repo.Storer.Reference(ref.Name())
+
+	if err == plumbing.ErrReferenceNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if currentRef.Hash() != ref.Hash() {
+		return errors.Errorf("Tag %s has changed concurrently", ref.Name().Short())
+	}
+
+	return repo.Storer.RemoveReference(ref.Name())
+}
+
+func lockRef(repo *git.Repository, refName plumbing.ReferenceName) (func(), error) {
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+
+	if !ok {
+		// other storages (e.g. in-memory storages) are not shared with other processes
+		return func() {}, nil
+	}
+
+	fs := storage.Filesystem()
+	lockFile := refName.String() + ".lock"
+
+	if err := fs.MkdirAll(path.Dir(lockFile), 0777); err != nil {
+		return nil, errors.WithMessage(err, "Could not create directory for "+lockFile)
+	}
+
+	delay := LOCK_RETRY_DELAY
+
+	for attempt := 1; ; attempt++ {
+
+		f, err := fs.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+
+		if err == nil {
+			return func() {
+				f.Close()
+				fs.Remove(lockFile)
+			}, nil
+		} else if !os.IsExist(err) {
+			return nil, errors.WithMessage(err, "Could not lock "+refName.String())
+		}
+
+		// Some other process is currently updating this reference
+		if attempt >= LOCK_ATTEMPTS {
+			return nil, errors.WithMessage(ErrRefLocked, "Could not lock "+refName.String())
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
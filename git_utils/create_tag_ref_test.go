@@ -0,0 +1,52 @@
+package git_utils
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateTagRef_should_fail_if_tag_exists(t *testing.T) {
+	repo, _ := test_utils.InitRepo(t)
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+
+	_, err := CreateTagRef(repo, "v1.0.0", hash)
+
+	assert.Equal(t, git.ErrTagExists, err)
+}
+
+func TestCreateTagRef_should_not_report_locked_tag_as_existing(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+
+	lockFile := filepath.Join(dir, ".git", "refs", "tags", "v1.0.0.lock")
+
+	if err := os.MkdirAll(filepath.Dir(lockFile), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(lockFile, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := CreateTagRef(repo, "v1.0.0", hash)
+
+	assert.Equal(t, ErrRefLocked, errors.Cause(err))
+	assert.EqualError(t, err, "Could not lock refs/tags/v1.0.0: Reference is locked by another process")
+
+	if err = os.Remove(lockFile); err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := CreateTagRef(repo, "v1.0.0", hash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
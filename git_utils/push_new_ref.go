@@ -0,0 +1,122 @@
+package git_utils
+
+import (
+	"context"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// Pushes ref to the remote, but only if the remote does not have a reference with the same name yet.
+// Unlike git.Remote.Push, which sends the advertised hash of an existing remote reference as old value and therefore
+// allows fast-forward updates, the update is sent with the zero hash as old value. The remote rejects it atomically if
+// the reference exists.
+func PushNewRef(repo *git.Repository, remoteName string, auth transport.AuthMethod, ref *plumbing.Reference) error {
+
+	remote, err := repo.Remote(remoteName)
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not find remote "+remoteName)
+	}
+
+	urls := remote.Config().URLs
+
+	if len(urls) == 0 {
+		return errors.New("Remote " + remoteName + " has no URL")
+	}
+
+	endpoint, err := transport.NewEndpoint(urls[0])
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not parse URL of remote "+remoteName)
+	}
+
+	transportClient, err := client.NewClient(endpoint)
+
+	if err != nil {
+		return err
+	}
+
+	session, err := transportClient.NewReceivePackSession(endpoint, auth)
+
+	if err != nil {
+		return err
+	}
+
+	defer session.Close()
+
+	advertisedRefs, err := session.AdvertisedReferences()
+
+	if err != nil {
+		return err
+	}
+
+	remoteRefs, err := advertisedRefs.AllReferences()
+
+	if err != nil {
+		return err
+	}
+
+	var haves []plumbing.Hash
+
+	for _, remoteRef := range remoteRefs {
+		if remoteRef.Type() == plumbing.HashReference {
+			haves = append(haves, remoteRef.Hash())
+		}
+	}
+
+	hashes, err := revlist.Objects(repo.Storer, []plumbing.Hash{ref.Hash()}, haves)
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not determine objects to push")
+	}
+
+	req := packp.NewReferenceUpdateRequestFromCapabilities(advertisedRefs.Capabilities)
+	req.Commands = []*packp.Command{{Name: ref.Name(), Old: plumbing.ZeroHash, New: ref.Hash()}}
+
+	cfg, err := repo.Storer.Config()
+
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	req.Packfile = reader
+	// buffered, so the encoder does not block if ReceivePack fails
+	done := make(chan error, 1)
+
+	go func() {
+		encoder := packfile.NewEncoder(writer, repo.Storer, !advertisedRefs.Capabilities.Supports(capability.OFSDelta))
+
+		if _, err := encoder.Encode(hashes, cfg.Pack.Window); err != nil {
+			done <- writer.CloseWithError(err)
+			return
+		}
+
+		done <- writer.Close()
+	}()
+
+	status, err := session.ReceivePack(context.Background(), req)
+
+	if err != nil {
+		reader.Close()
+		return err
+	}
+
+	if err = <-done; err != nil {
+		return err
+	}
+
+	if status != nil {
+		return status.Error()
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package git_utils
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPushNewRef_should_push_new_tag(t *testing.T) {
+	repo, _ := test_utils.InitRepo(t)
+	remoteRepo, _ := test_utils.InitRemote(t, repo, "origin")
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+
+	err := PushNewRef(repo, "origin", nil, plumbing.NewHashReference("refs/tags/v1.0.0", hash))
+
+	assert.Nil(t, err)
+
+	ref, err := remoteRepo.Tag("v1.0.0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
+
+func TestPushNewRef_should_not_fast_forward_existing_tag(t *testing.T) {
+	repo, _ := test_utils.InitRepo(t)
+	remoteRepo, _ := test_utils.InitRemote(t, repo, "origin")
+
+	first := test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.PushBranches(t, repo, "origin")
+	second := test_utils.Commit(t, repo, "feat: Add another feature")
+
+	if err := remoteRepo.Storer.SetReference(plumbing.NewHashReference("refs/tags/v1.0.0", first)); err != nil {
+		t.Fatal(err)
+	}
+
+	err := PushNewRef(repo, "origin", nil, plumbing.NewHashReference("refs/tags/v1.0.0", second))
+
+	assert.NotNil(t, err)
+
+	ref, err := remoteRepo.Tag("v1.0.0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, first, ref.Hash())
+}
@@ -0,0 +1,216 @@
+package git_utils
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/logger"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Environment variables, which may contain a token for HTTP(S) remotes, and the username to use with them.
+// The first non-empty variable, which applies to the host of the remote, wins.
+var TokenEnvVars = []struct {
+	Name     string
+	Username string
+	// UsernameEnvVar is the environment variable, which may override Username
+	UsernameEnvVar string
+	// Hosts returns the hosts, which the token is sent to. The token is sent to all hosts if Hosts is nil.
+	Hosts func() []string
+}{
+	{"GIT_SEMVER_TOKEN", "git-semver", "GIT_SEMVER_USERNAME", nil},
+	{"GITHUB_TOKEN", "x-access-token", "", githubHosts},
+	{"GITLAB_TOKEN", "oauth2", "", gitlabHosts},
+	{"CI_JOB_TOKEN", "gitlab-ci-token", "", gitlabHosts},
+}
+
+// Returns github.com and the host of GITHUB_SERVER_URL (e.g. a GitHub Enterprise Server of GitHub Actions).
+func githubHosts() []string {
+
+	hosts := []string{"github.com"}
+
+	if serverURL, err := url.Parse(os.Getenv("GITHUB_SERVER_URL")); err == nil && serverURL.Hostname() != "" {
+		hosts = append(hosts, serverURL.Hostname())
+	}
+
+	return hosts
+}
+
+// Returns the host of the GitLab instance of GitLab CI (CI_SERVER_HOST).
+func gitlabHosts() []string {
+
+	if host := os.Getenv("CI_SERVER_HOST"); host != "" {
+		return []string{host}
+	}
+
+	return nil
+}
+
+// Returns true if the token of the environment variable may be sent to host.
+func tokenAppliesTo(hosts func() []string, host string) bool {
+
+	if hosts == nil {
+		return true
+	}
+
+	for _, h := range hosts() {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Determines the authentication method for a remote:
+//
+// - SSH remotes use the SSH agent (SSH_AUTH_SOCK).
+// - HTTP(S) remotes use credentials from the URL, a token from one of the TokenEnvVars or the configured git credential helpers.
+//
+// GITHUB_TOKEN is only sent to GitHub. GITLAB_TOKEN and CI_JOB_TOKEN are only sent to the GitLab instance of GitLab CI.
+// Tokens and credentials of credential helpers are never sent over plain HTTP.
+//
+// Returns nil if no authentication method could be determined.
+func RemoteAuth(repo *git.Repository, remoteName string) (transport.AuthMethod, error) {
+
+	remote, err := repo.Remote(remoteName)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not find remote "+remoteName)
+	}
+
+	urls := remote.Config().URLs
+
+	if len(urls) == 0 {
+		return nil, errors.New("Remote " + remoteName + " has no URL")
+	}
+
+	endpoint, err := transport.NewEndpoint(urls[0])
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not parse URL of remote "+remoteName)
+	}
+
+	switch endpoint.Protocol {
+	case "ssh":
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			return nil, nil
+		}
+
+		user := endpoint.User
+
+		if user == "" {
+			user = ssh.DefaultUsername
+		}
+
+		auth, err := ssh.NewSSHAgentAuth(user)
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not connect to SSH agent")
+		}
+
+		return auth, nil
+	case "http", "https":
+		if endpoint.Password != "" {
+			return &http.BasicAuth{Username: endpoint.User, Password: endpoint.Password}, nil
+		}
+
+		if endpoint.Protocol == "http" {
+			logger.Logger.Warnln("Not sending credentials to remote", remoteName, "over unencrypted HTTP")
+			return nil, nil
+		}
+
+		for _, tokenEnvVar := range TokenEnvVars {
+			token := os.Getenv(tokenEnvVar.Name)
+
+			if token == "" || !tokenAppliesTo(tokenEnvVar.Hosts, endpoint.Host) {
+				continue
+			}
+
+			username := tokenEnvVar.Username
+
+			if tokenEnvVar.UsernameEnvVar != "" && os.Getenv(tokenEnvVar.UsernameEnvVar) != "" {
+				username = os.Getenv(tokenEnvVar.UsernameEnvVar)
+			}
+
+			logger.Logger.Debugln("Using token from", tokenEnvVar.Name, "for remote", remoteName)
+
+			return &http.BasicAuth{Username: username, Password: token}, nil
+		}
+
+		workdir := ""
+
+		if worktree, err := repo.Worktree(); err == nil {
+			workdir = worktree.Filesystem.Root()
+		}
+
+		return credentialHelperAuth(endpoint, workdir), nil
+	}
+
+	return nil, nil
+}
+
+// Asks the configured git credential helpers for credentials via "git credential fill".
+func credentialHelperAuth(endpoint *transport.Endpoint, workdir string) transport.AuthMethod {
+
+	host := endpoint.Host
+
+	if endpoint.Port != 0 {
+		host += ":" + strconv.Itoa(endpoint.Port)
+	}
+
+	input := "protocol=" + endpoint.Protocol + "\nhost=" + host
+	input += "\npath=" + strings.TrimPrefix(endpoint.Path, "/")
+
+	if endpoint.User != "" {
+		input += "\nusername=" + endpoint.User
+	}
+
+	input += "\n\n"
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Dir = workdir
+	cmd.Stdin = strings.NewReader(input)
+	// prevent git from prompting for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+
+	output, err := cmd.Output()
+
+	if err != nil {
+		logger.Logger.Debugln("Could not get credentials from git credential helper:", err)
+		return nil
+	}
+
+	auth := &http.BasicAuth{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "username":
+			auth.Username = value
+		case "password":
+			auth.Password = value
+		}
+	}
+
+	if auth.Password == "" {
+		return nil
+	}
+
+	return auth
+}
@@ -0,0 +1,219 @@
+package ignore
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/logger"
+	"regexp"
+	"strings"
+)
+
+// DEFAULT_MESSAGES match fixup and squash commits and "[skip release]" markers
+var DEFAULT_MESSAGES = []string{`^(fixup|squash|amend)! `, `(?i)\[(skip release|release skip)\]`}
+
+// footerRegex matches the footers of the final paragraph of a commit message
+var footerRegex = regexp.MustCompile(`(?m)^([a-zA-Z0-9_\-]+|BREAKING[ \-]CHANGE)(?::[ \t]*| #)(.*)$`)
+var paragraphSeparatorRegex = regexp.MustCompile(`\n[ \t]*\n`)
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+
+// Rules decide which commits are ignored. A nil *Rules does not ignore any commits.
+type Rules struct {
+	authors    []*regexp.Regexp
+	committers []*regexp.Regexp
+	messages   []*regexp.Regexp
+	footers    []footer
+	commits    []string
+}
+
+// footer matches a footer token and the value of the footer if value is not empty
+type footer struct {
+	token string
+	value string
+}
+
+// Ignored is a commit, which was ignored by the rules
+type Ignored struct {
+	Commit *object.Commit
+	// Reason describes the matching rule
+	Reason string
+}
+
+// Creates the rules of the configuration. DEFAULT_MESSAGES is used if no message patterns are configured. Returns nil
+// rules, which do not ignore any commits, if cfg is nil (i.e. there is no ignore section in the configuration file).
+func NewRules(cfg *config.IgnoreConfig) (*Rules, error) {
+
+	if cfg == nil {
+		return nil, nil
+	}
+
+	rules := &Rules{}
+	var err error
+
+	if rules.authors, err = compileAll(cfg.Authors, "author"); err != nil {
+		return nil, err
+	}
+
+	if rules.committers, err = compileAll(cfg.Committers, "committer"); err != nil {
+		return nil, err
+	}
+
+	messages := cfg.Messages
+
+	if messages == nil {
+		messages = DEFAULT_MESSAGES
+	}
+
+	if rules.messages, err = compileAll(messages, "message"); err != nil {
+		return nil, err
+	}
+
+	for _, f := range cfg.Footers {
+		token, value, _ := strings.Cut(f, ":")
+		token = strings.TrimSpace(token)
+
+		if token == "" {
+			return nil, errors.Errorf("Invalid ignored footer \"%s\"", f)
+		}
+
+		rules.footers = append(rules.footers, footer{token: token, value: strings.TrimSpace(value)})
+	}
+
+	for _, hash := range cfg.Commits {
+		hash = strings.ToLower(strings.TrimSpace(hash))
+
+		if !commitHashRegex.MatchString(hash) {
+			return nil, errors.Errorf("Invalid ignored commit \"%s\" (expected a hash with at least 4 characters)", hash)
+		}
+
+		rules.commits = append(rules.commits, hash)
+	}
+
+	return rules, nil
+}
+
+func compileAll(patterns []string, name string) ([]*regexp.Regexp, error) {
+
+	var ret []*regexp.Regexp
+
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not parse ignored "+name+" pattern "+pattern)
+		}
+
+		ret = append(ret, regex)
+	}
+
+	return ret, nil
+}
+
+// Returns the reason why the commit is ignored or an empty string if it is not ignored.
+func (r *Rules) Match(commit *object.Commit) string {
+
+	if r == nil {
+		return ""
+	}
+
+	hash := commit.Hash.String()
+
+	for _, prefix := range r.commits {
+		if strings.HasPrefix(hash, prefix) {
+			return fmt.Sprintf("commit %s is ignored", prefix)
+		}
+	}
+
+	author := signature(commit.Author)
+
+	for _, regex := range r.authors {
+		if regex.MatchString(author) {
+			return fmt.Sprintf("author \"%s\" matches %s", author, regex)
+		}
+	}
+
+	committer := signature(commit.Committer)
+
+	for _, regex := range r.committers {
+		if regex.MatchString(committer) {
+			return fmt.Sprintf("committer \"%s\" matches %s", committer, regex)
+		}
+	}
+
+	for _, regex := range r.messages {
+		if regex.MatchString(commit.Message) {
+			return fmt.Sprintf("message matches %s", regex)
+		}
+	}
+
+	if len(r.footers) == 0 {
+		return ""
+	}
+
+	for _, match := range footerRegex.FindAllStringSubmatch(footers(commit.Message), -1) {
+		for _, f := range r.footers {
+			if !strings.EqualFold(f.token, match[1]) {
+				continue
+			}
+
+			if f.value == "" {
+				return fmt.Sprintf("footer %s", f.token)
+			}
+
+			if strings.EqualFold(f.value, strings.TrimSpace(match[2])) {
+				return fmt.Sprintf("footer \"%s: %s\"", f.token, f.value)
+			}
+		}
+	}
+
+	return ""
+}
+
+// Returns the final paragraph of the message if it starts with a footer. Otherwise there are no footers. Like in the
+// conventional commits parser, footer-like lines in the body (e.g. "Note: ...") are no footers.
+func footers(message string) string {
+
+	// the first line is no footer
+	_, rest, found := strings.Cut(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+
+	if !found {
+		return ""
+	}
+
+	paragraphs := paragraphSeparatorRegex.Split(strings.TrimSpace(rest), -1)
+	last := strings.TrimSpace(paragraphs[len(paragraphs)-1])
+
+	if match := footerRegex.FindStringIndex(last); match == nil || match[0] != 0 {
+		return ""
+	}
+
+	return last
+}
+
+// Returns the commits, which are not ignored, and the ignored commits.
+func (r *Rules) Filter(commits []*object.Commit) ([]*object.Commit, []*Ignored) {
+
+	if r == nil {
+		return commits, nil
+	}
+
+	ret := make([]*object.Commit, 0, len(commits))
+	var ignored []*Ignored
+
+	for _, commit := range commits {
+		if reason := r.Match(commit); reason != "" {
+			logger.Logger.Debugln("Ignoring commit", commit.Hash.String(), "("+reason+")")
+			ignored = append(ignored, &Ignored{Commit: commit, Reason: reason})
+			continue
+		}
+
+		ret = append(ret, commit)
+	}
+
+	return ret, ignored
+}
+
+func signature(s object.Signature) string {
+	return s.Name + " <" + s.Email + ">"
+}
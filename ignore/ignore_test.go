@@ -0,0 +1,130 @@
+package ignore
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+const HASH = "0123456789abcdef0123456789abcdef01234567"
+
+func testCommit(message string) *object.Commit {
+	signature := object.Signature{Name: "Jane Doe", Email: "jane@example.com"}
+
+	return &object.Commit{
+		Hash:      plumbing.NewHash(HASH),
+		Author:    signature,
+		Committer: signature,
+		Message:   message,
+	}
+}
+
+func newRules(t *testing.T, cfg config.IgnoreConfig) *Rules {
+	rules, err := NewRules(&cfg)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rules
+}
+
+func TestRules_should_ignore_nothing_if_nil(t *testing.T) {
+	var rules *Rules
+
+	assert.Equal(t, "", rules.Match(testCommit("fixup! feat: Add feature")))
+}
+
+func TestNewRules_should_ignore_nothing_without_ignore_section(t *testing.T) {
+	cfg := config.Config{}
+	assert.Nil(t, yaml.Unmarshal([]byte("parser:\n  mode: strict\n"), &cfg))
+
+	rules, err := NewRules(cfg.Ignore)
+
+	assert.Nil(t, err)
+	assert.Nil(t, rules)
+	assert.Equal(t, "", rules.Match(testCommit("fixup! feat: Add feature")))
+}
+
+func TestRules_should_ignore_default_messages(t *testing.T) {
+	rules := newRules(t, config.IgnoreConfig{})
+
+	assert.Equal(t, "message matches ^(fixup|squash|amend)! ", rules.Match(testCommit("fixup! feat: Add feature")))
+	assert.Equal(t, "message matches ^(fixup|squash|amend)! ", rules.Match(testCommit("squash! fix: Fix bug")))
+	assert.Equal(t, `message matches (?i)\[(skip release|release skip)\]`, rules.Match(testCommit("docs: Fix typo [skip release]")))
+	assert.Equal(t, "", rules.Match(testCommit("feat: Add feature")))
+}
+
+func TestRules_should_not_use_default_messages_if_disabled(t *testing.T) {
+	cfg := config.Config{}
+	assert.Nil(t, yaml.Unmarshal([]byte("ignore:\n  messages: []\n"), &cfg))
+
+	rules := newRules(t, *cfg.Ignore)
+
+	assert.Equal(t, "", rules.Match(testCommit("fixup! feat: Add feature")))
+}
+
+func TestRules_should_ignore_authors_and_committers(t *testing.T) {
+	commit := testCommit("fix(deps): Update dependency")
+	commit.Author = object.Signature{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"}
+	commit.Committer = object.Signature{Name: "CI Bot", Email: "ci@example.com"}
+
+	authorRules := newRules(t, config.IgnoreConfig{Authors: []string{`^dependabot`}})
+	committerRules := newRules(t, config.IgnoreConfig{Committers: []string{`<ci@example\.com>$`}})
+
+	assert.Equal(t, `author "dependabot[bot] <49699333+dependabot[bot]@users.noreply.github.com>" matches ^dependabot`, authorRules.Match(commit))
+	assert.Equal(t, `committer "CI Bot <ci@example.com>" matches <ci@example\.com>$`, committerRules.Match(commit))
+	assert.Equal(t, "", authorRules.Match(testCommit("fix: Fix bug")))
+}
+
+func TestRules_should_ignore_footers(t *testing.T) {
+	rules := newRules(t, config.IgnoreConfig{Footers: []string{"Release-Note: none", "Skip-Release"}})
+
+	assert.Equal(t, `footer "Release-Note: none"`, rules.Match(testCommit("fix: Fix bug\n\nBody\n\nRelease-Note: None")))
+	assert.Equal(t, "footer Skip-Release", rules.Match(testCommit("fix: Fix bug\n\nskip-release: true")))
+	assert.Equal(t, "", rules.Match(testCommit("fix: Fix bug\n\nRelease-Note: Fixes the bug")))
+	assert.Equal(t, "", rules.Match(testCommit("Skip-Release: in the first line")))
+}
+
+func TestRules_should_only_match_footers_of_final_paragraph(t *testing.T) {
+	rules := newRules(t, config.IgnoreConfig{Footers: []string{"Note", "Skip-Release"}})
+
+	assert.Equal(t, "", rules.Match(testCommit("fix: Fix bug\n\nNote: The bug was old.\n\nSee the issue.")))
+	assert.Equal(t, "", rules.Match(testCommit("fix: Fix bug\n\nThe cache\nSkip-release: is not used here\n\nRefs: #1")))
+	assert.Equal(t, "", rules.Match(testCommit("fix: Fix bug\n\nThe cache is cleared.\nNote: once")))
+	assert.Equal(t, "footer Note", rules.Match(testCommit("fix: Fix bug\r\n\r\nBody\r\n  \r\nRefs: #1\r\nNote: once\r\n")))
+}
+
+func TestRules_should_ignore_commits(t *testing.T) {
+	rules := newRules(t, config.IgnoreConfig{Commits: []string{"0123456"}})
+	other := testCommit("fix: Fix bug")
+	other.Hash = plumbing.NewHash("f123456789abcdef0123456789abcdef01234567")
+
+	assert.Equal(t, "commit 0123456 is ignored", rules.Match(testCommit("fix: Fix bug")))
+	assert.Equal(t, "", rules.Match(other))
+}
+
+func TestNewRules_should_fail_on_invalid_rules(t *testing.T) {
+	_, err := NewRules(&config.IgnoreConfig{Authors: []string{"("}})
+	assert.NotNil(t, err)
+
+	_, err = NewRules(&config.IgnoreConfig{Footers: []string{": none"}})
+	assert.EqualError(t, err, "Invalid ignored footer \": none\"")
+
+	_, err = NewRules(&config.IgnoreConfig{Commits: []string{"abc"}})
+	assert.EqualError(t, err, "Invalid ignored commit \"abc\" (expected a hash with at least 4 characters)")
+}
+
+func TestRules_Filter_should_return_ignored_commits(t *testing.T) {
+	rules := newRules(t, config.IgnoreConfig{})
+	fixup := testCommit("fixup! feat: Add feature")
+	feature := testCommit("feat: Add feature")
+
+	commits, ignored := rules.Filter([]*object.Commit{fixup, feature})
+
+	assert.Equal(t, []*object.Commit{feature}, commits)
+	assert.Equal(t, []*Ignored{{Commit: fixup, Reason: "message matches ^(fixup|squash|amend)! "}}, ignored)
+}
@@ -0,0 +1,46 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+public class BumpCmdTests {
+
+    @Test
+    public void shouldUpdateVersionFile() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("sh", "-c", "echo 1.0.0 > VERSION");
+            container.gitAdd("VERSION");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("fix: Add fix");
+
+            assertThat(container.exec("git", "semver", "bump")).isEqualTo("VERSION\n");
+            assertThat(container.exec("cat", "VERSION")).isEqualTo("1.0.1\n");
+        }
+
+    }
+
+    @Test
+    public void shouldFailCheckIfFileIsOutdated() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("sh", "-c", "echo 0.9.0 > VERSION");
+            container.gitAdd("VERSION");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+
+            assertThatThrownBy(() -> container.exec("git", "semver", "bump", "--check"))
+                .hasMessageContaining("VERSION: found 0.9.0 (expected 1.0.0)");
+        }
+
+    }
+}
@@ -0,0 +1,58 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+public class ChangelogCmdTests {
+
+    @Test
+    public void shouldPrintSectionForEachVersion() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("feat: Add other feature");
+            container.gitTag("v1.1.0-rc.1");
+            container.addNewFileToGit("file3.txt");
+            container.gitCommit("fix: Add fix");
+            container.gitTag("v1.1.0");
+            container.addNewFileToGit("file4.txt");
+            container.gitCommit("fix: Add unreleased fix");
+
+            var changelog = container.exec("git", "semver", "changelog", "--style", "conventional");
+
+            assertThat(changelog)
+                .contains("## Unreleased\n\n### Bug Fixes\n\n* Add unreleased fix\n")
+                .contains("### Features\n\n* Add other feature\n\n### Bug Fixes\n\n* Add fix\n")
+                .contains("## 1.0.0 (")
+                .doesNotContain("1.1.0-rc.1");
+        }
+
+    }
+
+    @Test
+    public void shouldPrintSeparatePreReleaseSections() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0-rc.1");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+            container.gitTag("v1.0.0");
+
+            assertThat(container.exec("git", "semver", "changelog", "--separate-pre-releases", "--style", "conventional"))
+                .contains("## 1.0.0 (")
+                .contains("## 1.0.0-rc.1 (");
+        }
+
+    }
+}
@@ -0,0 +1,47 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+public class GenerateCmdTests {
+
+    @Test
+    public void shouldRenderBuiltInTemplate() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+
+            assertThat(container.exec("git", "semver", "generate", "--template", "properties", "--output", "version.properties", "--build-metadata", "build.5"))
+                .isEqualTo("version.properties\n");
+            assertThat(container.exec("cat", "version.properties"))
+                .contains("version=1.0.1+build.5\n")
+                .contains("version.tag=v1.0.1\n");
+        }
+
+    }
+
+    @Test
+    public void shouldPassCheckIfFileIsUpToDate() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+
+            container.exec("git", "semver", "generate", "--latest", "--template", "typescript", "--output", "src/version.ts");
+
+            assertThat(container.exec("git", "semver", "generate", "--check", "--template", "typescript", "--output", "src/version.ts")).isEmpty();
+        }
+
+    }
+}
@@ -297,4 +297,151 @@ public class LogCmdTests {
 
     }
 
+    @Test
+    public void shouldPrintLogRenderedWithTemplate() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix(some_component): Add fix");
+            container.exec("sh", "-c", "printf '{{ range .Commits }}{{ .ChangeType }}|{{ .Scope }}|{{ .Description | upper }}\\n{{ end }}' > /tmp/release-notes.tmpl");
+
+            assertThat(container.exec("git", "semver", "log", "--template", "/tmp/release-notes.tmpl"))
+                .isEqualTo("fix|some_component|ADD FIX\n"
+                    + "feat||ADD FEATURE\n"
+                );
+        }
+
+    }
+
+    @Test
+    public void shouldPrintLogInTextFormat() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix(some_component): Add fix");
+
+            assertThat(container.exec("git", "semver", "log", "--format", "text"))
+                .isEqualTo("Features:\n"
+                    + "  - Add feature\n"
+                    + "\n"
+                    + "Bug Fixes:\n"
+                    + "  - some_component: Add fix\n"
+                );
+        }
+
+    }
+
+    @Test
+    public void shouldPrintLogWithPrettyFormat() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix(some_component)!: Add fix");
+
+            assertThat(container.exec("git", "semver", "log", "--pretty", "format:%(type)%(breaking)|%(scope)|%(description)|%s"))
+                .isEqualTo("fix!|some_component|Add fix|fix(some_component)!: Add fix\n"
+                    + "feat||Add feature|feat: Add feature\n"
+                );
+        }
+
+    }
+
+    @Test
+    public void shouldPrintLogOfVersionRange() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+            container.gitTag("v1.0.1");
+            container.addNewFileToGit("file3.txt");
+            container.gitCommit("feat: Add another feature");
+            container.gitTag("v1.1.0");
+            container.addNewFileToGit("file4.txt");
+            container.gitCommit("fix: Unreleased fix");
+
+            assertThat(container.exec("git", "semver", "log", "--markdown", "1.0.0..1.1.0"))
+                .isEqualTo("### Features\n"
+                    + "\n"
+                    + "* Add another feature\n"
+                    + "\n"
+                    + "### Bug Fixes\n"
+                    + "\n"
+                    + "* Add fix\n"
+                );
+
+            assertThat(container.exec("git", "semver", "log", "--pretty", "format:%s", "v1.1.0.."))
+                .isEqualTo("fix: Unreleased fix\n");
+        }
+
+    }
+
+    @Test
+    public void shouldPrintFilteredLog() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat(api)!: Replace endpoint");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix(api): Fix endpoint");
+            container.addNewFileToGit("file3.txt");
+            container.gitCommit("fix(ui): Fix button");
+
+            assertThat(container.exec("git", "semver", "log", "--type", "fix", "--scope", "a*", "--pretty", "format:%s"))
+                .isEqualTo("fix(api): Fix endpoint\n");
+
+            assertThat(container.exec("git", "semver", "log", "--breaking-only", "--pretty", "format:%s"))
+                .isEqualTo("feat(api)!: Replace endpoint\n");
+
+            assertThat(container.exec("git", "semver", "log", "--path", "file3.txt", "--markdown"))
+                .isEqualTo("### Bug Fixes\n"
+                    + "\n"
+                    + "* **ui** Fix button\n"
+                );
+        }
+
+    }
+
+    @Test
+    public void shouldControlLogEntriesViaFooters() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("fix: Fix typo\n\nChangelog: skip");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix(auth): Validate token audience\n\nChangelog: Reject tokens issued for other services\nChangelog-Section: Security\nRelease-Note: Tokens without audience are rejected.");
+
+            assertThat(container.exec("git", "semver", "log", "--markdown"))
+                .isEqualTo("### Release Notes\n"
+                    + "\n"
+                    + "* **auth** Tokens without audience are rejected.\n"
+                    + "\n"
+                    + "### Security\n"
+                    + "\n"
+                    + "* **auth** Reject tokens issued for other services\n"
+                );
+        }
+
+    }
+
 }
@@ -346,4 +346,101 @@ public class NextCmdTests {
 
     }
 
+    @Test
+    public void shouldIgnoreNonConformingCommitsInStrictParserMode() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: First Version");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("feat:Missing space");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.1.0");
+            assertThat(container.exec("git", "semver", "next", "--parser-mode", "strict")).isEqualTo("1.0.0");
+        }
+
+    }
+
+    @Test
+    public void shouldUseGitmojiParserPreset() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("sh", "-c", "printf 'parser:\\n  preset: gitmoji\\n' > .git-semver.yaml");
+            container.gitAdd(".git-semver.yaml");
+            container.gitCommit(":tada: Initial commit");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file.txt");
+            container.gitCommit(":sparkles: Add feature");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.1.0");
+        }
+
+    }
+
+    @Test
+    public void shouldIgnoreConfiguredCommits() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("sh", "-c", "printf 'ignore:\\n  footers:\\n    - \"Release-Note: none\"\\n' > .git-semver.yaml");
+            container.gitAdd(".git-semver.yaml");
+            container.gitCommit("feat: First Version");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("fix: Fix bug");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fixup! feat: Add feature");
+            container.addNewFileToGit("file3.txt");
+            container.gitCommit("feat: Add internal feature\n\nRelease-Note: none");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.0.1");
+        }
+
+    }
+
+    @Test
+    public void shouldApplyOverridesFromGitNotes() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: First Version");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("feat: Fix bug");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.1.0");
+
+            container.exec("git", "notes", "--ref", "semver", "add", "-m", "type: fix", "HEAD");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.0.1");
+        }
+
+    }
+
+    @Test
+    public void shouldEnforcePatchReleaseForSecurityFixes() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: First Version");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("chore(deps): Bump yaml\n\nRefs: CVE-2024-12345");
+
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.0.0");
+            assertThat(container.exec("git", "semver", "next", "--security-patch")).isEqualTo("1.0.1");
+        }
+
+    }
+
 }
@@ -0,0 +1,50 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+public class ReleaseCmdTests {
+
+    @Test
+    public void shouldCommitChangelogAndTagReleaseCommit() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+
+            assertThat(container.exec("git", "semver", "release")).isEqualTo("1.0.1");
+            assertThat(container.exec("git", "log", "-1", "--format=%s")).contains("chore(release): 1.0.1");
+            assertThat(container.exec("git", "tag", "--points-at", "HEAD")).contains("v1.0.1");
+            assertThat(container.exec("cat", "CHANGELOG.md"))
+                .contains("## 1.0.1")
+                .contains("* Add fix");
+            assertThat(container.exec("git", "semver", "next")).isEqualTo("1.0.1");
+        }
+
+    }
+
+    @Test
+    public void shouldOnlyPrintPlannedChangesOnDryRun() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+
+            assertThat(container.exec("git", "semver", "release", "--dry-run"))
+                .contains("Version: 1.0.0")
+                .contains("+++ b/CHANGELOG.md")
+                .contains("+* Add feature");
+            assertThat(container.exec("git", "tag")).isEmpty();
+        }
+
+    }
+}
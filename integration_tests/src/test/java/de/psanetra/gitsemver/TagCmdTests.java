@@ -0,0 +1,99 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatCode;
+
+public class TagCmdTests {
+
+    @Test
+    public void shouldTagHeadWithNextVersion() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+
+            assertThat(container.exec("git", "semver", "tag")).isEqualTo("1.0.1");
+            assertThat(container.exec("git", "tag", "--points-at", "HEAD")).contains("v1.0.1");
+        }
+
+    }
+
+    @Test
+    public void shouldFailIfReleaseTagAlreadyExists() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+
+            assertThatCode(() -> container.exec("git", "semver", "tag"))
+                .hasMessageContaining("Tag v1.0.0 already exists");
+        }
+
+    }
+
+    @Test
+    public void shouldIncrementPreReleaseCounter() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0-rc.1");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("fix: Add fix");
+
+            assertThat(container.exec("git", "semver", "tag", "--pre-release-tag=rc", "--pre-release-counter"))
+                .isEqualTo("1.0.0-rc.2");
+        }
+
+    }
+
+    @Test
+    public void shouldPushTagToRemote() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("git", "init", "--bare", "/remote");
+            container.exec("git", "remote", "add", "origin", "/remote");
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+
+            assertThat(container.exec("git", "semver", "tag", "--push")).isEqualTo("1.0.0");
+            assertThat(container.exec("git", "--git-dir=/remote", "tag")).contains("v1.0.0");
+        }
+
+    }
+
+    @Test
+    public void shouldReportConflictingRemoteTag() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.exec("git", "init", "--bare", "/remote");
+            container.exec("git", "remote", "add", "origin", "/remote");
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add feature");
+            container.gitTag("v1.0.0");
+            container.exec("git", "push", "origin", "HEAD", "v1.0.0");
+            container.exec("git", "tag", "-d", "v1.0.0");
+
+            assertThatCode(() -> container.exec("git", "semver", "tag", "--push"))
+                .hasMessageContaining("Remote origin already has a conflicting tag v1.0.0");
+        }
+
+    }
+}
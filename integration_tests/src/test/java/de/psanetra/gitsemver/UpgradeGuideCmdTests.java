@@ -0,0 +1,46 @@
+package de.psanetra.gitsemver;
+
+import de.psanetra.gitsemver.containers.GitSemverContainer;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+public class UpgradeGuideCmdTests {
+
+    @Test
+    public void shouldPrintBreakingChangesAndDeprecationsOfAllVersions() {
+
+        try (var container = new GitSemverContainer()) {
+            container.start();
+
+            container.addNewFileToGit("file.txt");
+            container.gitCommit("feat: Add items");
+            container.gitTag("v1.3.0");
+            container.addNewFileToGit("file2.txt");
+            container.gitCommit("feat(api)!: Replace items endpoint\n\nBREAKING CHANGE: Use /v2/items instead of /items.");
+            container.addNewFileToGit("file3.txt");
+            container.gitCommit("feat(cli): Add --out flag\n\nDEPRECATED: Use --out instead of --output.");
+            container.gitTag("v2.0.0");
+            container.addNewFileToGit("file4.txt");
+            container.gitCommit("feat(cli)!: Remove --output flag");
+            container.gitTag("v4.1.0");
+
+            var guide = container.exec("git", "semver", "upgrade-guide", "1.3.0", "4.1.0");
+
+            assertThat(guide)
+                .startsWith("# Upgrade from 1.3.0 to 4.1.0\n")
+                .contains("### api\n\n#### Breaking Changes\n\n* Use /v2/items instead of /items.\n")
+                .contains("### cli\n\n#### Deprecations\n\n* Use --out instead of --output.\n")
+                .contains("### cli\n\n#### Breaking Changes\n\n* Remove --output flag\n");
+
+            assertThat(guide.indexOf("## 2.0.0")).isLessThan(guide.indexOf("## 4.1.0"));
+
+            assertThat(container.exec("git", "semver", "upgrade-guide", "2.0.0", "4.1.0", "--format", "json"))
+                .contains("\"from\": \"2.0.0\"")
+                .contains("\"version\": \"4.1.0\"")
+                .doesNotContain("\"version\": \"2.0.0\"");
+        }
+
+    }
+
+}
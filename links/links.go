@@ -0,0 +1,265 @@
+package links
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type Provider string
+
+const (
+	GITHUB       Provider = "github"
+	GITLAB       Provider = "gitlab"
+	BITBUCKET    Provider = "bitbucket"
+	GITEA        Provider = "gitea"
+	AZURE_DEVOPS Provider = "azure"
+)
+
+// DEFAULT_REMOTE is the remote, which is used to detect the hosting provider
+const DEFAULT_REMOTE = "origin"
+
+// Links contains the URL templates of a repository. The templates may contain the placeholders {hash} (commit),
+// {from} and {to} (compare) and {id} (issue and pull request). Empty templates produce no links.
+type Links struct {
+	Commit      string
+	Compare     string
+	Issue       string
+	PullRequest string
+}
+
+// url templates of the providers. {url} is replaced by the web url of the repository and {project} by the url of the
+// project containing the repository (Azure DevOps) or the repository url.
+var providerTemplates = map[Provider]Links{
+	GITHUB: {
+		Commit:      "{url}/commit/{hash}",
+		Compare:     "{url}/compare/{from}...{to}",
+		Issue:       "{url}/issues/{id}",
+		PullRequest: "{url}/pull/{id}",
+	},
+	GITLAB: {
+		Commit:      "{url}/-/commit/{hash}",
+		Compare:     "{url}/-/compare/{from}...{to}",
+		Issue:       "{url}/-/issues/{id}",
+		PullRequest: "{url}/-/merge_requests/{id}",
+	},
+	BITBUCKET: {
+		Commit:      "{url}/commits/{hash}",
+		Compare:     "{url}/branches/compare/{to}%0D{from}",
+		Issue:       "{url}/issues/{id}",
+		PullRequest: "{url}/pull-requests/{id}",
+	},
+	GITEA: {
+		Commit:      "{url}/commit/{hash}",
+		Compare:     "{url}/compare/{from}...{to}",
+		Issue:       "{url}/issues/{id}",
+		PullRequest: "{url}/pulls/{id}",
+	},
+	AZURE_DEVOPS: {
+		Commit:      "{url}/commit/{hash}",
+		Compare:     "{url}/branchCompare?baseVersion=GT{from}&targetVersion=GT{to}",
+		Issue:       "{project}/_workitems/edit/{id}",
+		PullRequest: "{url}/pullrequest/{id}",
+	},
+}
+
+// scpLikeURLRegex matches remote urls like "git@github.com:owner/repo.git"
+var scpLikeURLRegex = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+func ParseProvider(provider string) (Provider, error) {
+	switch Provider(provider) {
+	case GITHUB, GITLAB, BITBUCKET, GITEA, AZURE_DEVOPS:
+		return Provider(provider), nil
+	}
+
+	return "", errors.Errorf("Unknown provider \"%s\" (expected one of %s, %s, %s, %s, %s)", provider, GITHUB, GITLAB, BITBUCKET, GITEA, AZURE_DEVOPS)
+}
+
+// Resolves the links of the repository in workdir. The web url of the repository and the hosting provider are detected
+// from the remote url of DEFAULT_REMOTE unless they are configured. Templates in the configuration override the
+// templates of the provider. Returns nil if links are disabled. The templates are empty if the provider is unknown and
+// they are not configured.
+func Resolve(workdir string, cfg config.LinksConfig) (*Links, error) {
+
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	var provider Provider
+
+	if cfg.Provider != "" {
+		var err error
+		provider, err = ParseProvider(cfg.Provider)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repoURL := strings.TrimSuffix(cfg.URL, "/")
+
+	if repoURL == "" {
+		remoteURL, err := remoteURL(workdir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if remoteURL != "" {
+			var detected Provider
+			repoURL, detected = Detect(remoteURL)
+
+			if provider == "" {
+				provider = detected
+			}
+		}
+	} else if provider == "" {
+		_, provider = Detect(repoURL)
+	}
+
+	links := &Links{}
+
+	if provider != "" && repoURL != "" {
+		templates := providerTemplates[provider]
+		links = &templates
+	}
+
+	replacer := strings.NewReplacer("{url}", repoURL, "{project}", projectURL(repoURL))
+
+	for _, t := range []struct {
+		template   *string
+		configured string
+	}{
+		{&links.Commit, cfg.Commit},
+		{&links.Compare, cfg.Compare},
+		{&links.Issue, cfg.Issue},
+		{&links.PullRequest, cfg.PullRequest},
+	} {
+		if t.configured != "" {
+			*t.template = t.configured
+		}
+
+		*t.template = replacer.Replace(*t.template)
+	}
+
+	return links, nil
+}
+
+// Returns the web url of the repository and its hosting provider. The provider is empty if it is unknown.
+func Detect(remoteURL string) (string, Provider) {
+
+	var host, path string
+
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if match := scpLikeURLRegex.FindStringSubmatch(remoteURL); match != nil {
+		host, path = match[1], match[2]
+	} else {
+		return "", ""
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	switch {
+	case host == "ssh.dev.azure.com":
+		// git@ssh.dev.azure.com:v3/org/project/repo
+		parts := strings.Split(strings.TrimPrefix(path, "v3/"), "/")
+
+		if len(parts) != 3 {
+			return "", ""
+		}
+
+		return "https://dev.azure.com/" + parts[0] + "/" + parts[1] + "/_git/" + parts[2], AZURE_DEVOPS
+	case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+		// https://org@dev.azure.com/org/project/_git/repo
+		return "https://" + host + "/" + path, AZURE_DEVOPS
+	}
+
+	repoURL := "https://" + host + "/" + path
+
+	switch {
+	case host == "github.com" || strings.HasPrefix(host, "github."):
+		return repoURL, GITHUB
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return repoURL, GITLAB
+	case host == "bitbucket.org" || strings.HasPrefix(host, "bitbucket."):
+		return repoURL, BITBUCKET
+	case host == "codeberg.org" || strings.HasPrefix(host, "gitea."):
+		return repoURL, GITEA
+	}
+
+	return repoURL, ""
+}
+
+func remoteURL(workdir string) (string, error) {
+
+	repo, err := git.PlainOpenWithOptions(workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Could not open git repository")
+	}
+
+	remote, err := repo.Remote(DEFAULT_REMOTE)
+
+	if err == git.ErrRemoteNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.WithMessage(err, "Could not find remote "+DEFAULT_REMOTE)
+	}
+
+	if len(remote.Config().URLs) == 0 {
+		return "", nil
+	}
+
+	return remote.Config().URLs[0], nil
+}
+
+// Returns the url of the Azure DevOps project of a repository url or the repository url itself.
+func projectURL(repoURL string) string {
+	if i := strings.Index(repoURL, "/_git/"); i >= 0 {
+		return repoURL[:i]
+	}
+
+	return repoURL
+}
+
+// Returns the url of a commit or an empty string if there is no commit template.
+func (l *Links) CommitURL(hash string) string {
+	if l == nil || l.Commit == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(l.Commit, "{hash}", hash)
+}
+
+// Returns the url comparing two revisions or an empty string if there is no compare template or from is empty.
+func (l *Links) CompareURL(from string, to string) string {
+	if l == nil || l.Compare == "" || from == "" {
+		return ""
+	}
+
+	return strings.NewReplacer("{from}", url.PathEscape(from), "{to}", url.PathEscape(to)).Replace(l.Compare)
+}
+
+// Returns the url of an issue or an empty string if there is no issue template.
+func (l *Links) IssueURL(id string) string {
+	if l == nil || l.Issue == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(l.Issue, "{id}", id)
+}
+
+// Returns the url of a pull request or an empty string if there is no pull request template.
+func (l *Links) PullRequestURL(id string) string {
+	if l == nil || l.PullRequest == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(l.PullRequest, "{id}", id)
+}
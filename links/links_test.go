@@ -0,0 +1,132 @@
+package links
+
+import (
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDetect_should_detect_provider_and_url(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		url       string
+		provider  Provider
+	}{
+		{"https://github.com/owner/repo.git", "https://github.com/owner/repo", GITHUB},
+		{"git@github.com:owner/repo.git", "https://github.com/owner/repo", GITHUB},
+		{"ssh://git@gitlab.com/group/sub/repo.git", "https://gitlab.com/group/sub/repo", GITLAB},
+		{"https://user@bitbucket.org/team/repo.git", "https://bitbucket.org/team/repo", BITBUCKET},
+		{"https://codeberg.org/owner/repo", "https://codeberg.org/owner/repo", GITEA},
+		{"https://org@dev.azure.com/org/project/_git/repo", "https://dev.azure.com/org/project/_git/repo", AZURE_DEVOPS},
+		{"git@ssh.dev.azure.com:v3/org/project/repo", "https://dev.azure.com/org/project/_git/repo", AZURE_DEVOPS},
+		{"https://git.example.com/owner/repo.git", "https://git.example.com/owner/repo", ""},
+		{"/some/local/path", "", ""},
+	}
+
+	for _, test := range tests {
+		url, provider := Detect(test.remoteURL)
+
+		assert.Equal(t, test.url, url, test.remoteURL)
+		assert.Equal(t, test.provider, provider, test.remoteURL)
+	}
+}
+
+func TestLinks_should_render_urls(t *testing.T) {
+	links := &Links{
+		Commit:      "https://example.com/commit/{hash}",
+		Compare:     "https://example.com/compare/{from}...{to}",
+		Issue:       "https://example.com/issues/{id}",
+		PullRequest: "https://example.com/pulls/{id}",
+	}
+
+	assert.Equal(t, "https://example.com/commit/abc", links.CommitURL("abc"))
+	assert.Equal(t, "https://example.com/compare/v1.0.0...v1.1.0", links.CompareURL("v1.0.0", "v1.1.0"))
+	assert.Equal(t, "", links.CompareURL("", "v1.0.0"))
+	assert.Equal(t, "https://example.com/issues/1", links.IssueURL("1"))
+	assert.Equal(t, "https://example.com/pulls/2", links.PullRequestURL("2"))
+
+	var noLinks *Links
+
+	assert.Equal(t, "", noLinks.CommitURL("abc"))
+	assert.Equal(t, "", noLinks.CompareURL("v1.0.0", "v1.1.0"))
+}
+
+func TestResolve_should_detect_links_from_origin(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	addRemote(t, repo, "git@gitlab.com:group/repo.git")
+
+	links, err := Resolve(dir, config.LinksConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Links{
+		Commit:      "https://gitlab.com/group/repo/-/commit/{hash}",
+		Compare:     "https://gitlab.com/group/repo/-/compare/{from}...{to}",
+		Issue:       "https://gitlab.com/group/repo/-/issues/{id}",
+		PullRequest: "https://gitlab.com/group/repo/-/merge_requests/{id}",
+	}, links)
+}
+
+func TestResolve_should_apply_configuration(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	addRemote(t, repo, "https://git.example.com/group/repo.git")
+
+	links, err := Resolve(dir, config.LinksConfig{
+		Provider: "gitea",
+		Issue:    "https://jira.example.com/browse/{id}",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Links{
+		Commit:      "https://git.example.com/group/repo/commit/{hash}",
+		Compare:     "https://git.example.com/group/repo/compare/{from}...{to}",
+		Issue:       "https://jira.example.com/browse/{id}",
+		PullRequest: "https://git.example.com/group/repo/pulls/{id}",
+	}, links)
+}
+
+func TestResolve_should_use_project_url_for_azure_work_items(t *testing.T) {
+	_, dir := test_utils.InitRepo(t)
+
+	links, err := Resolve(dir, config.LinksConfig{URL: "https://dev.azure.com/org/project/_git/repo"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://dev.azure.com/org/project/_workitems/edit/{id}", links.Issue)
+}
+
+func TestResolve_should_return_nil_if_links_are_disabled(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	addRemote(t, repo, "https://github.com/owner/repo.git")
+
+	links, err := Resolve(dir, config.LinksConfig{Disabled: true})
+
+	assert.Nil(t, err)
+	assert.Nil(t, links)
+}
+
+func TestResolve_should_return_empty_templates_without_remote(t *testing.T) {
+	_, dir := test_utils.InitRepo(t)
+
+	links, err := Resolve(dir, config.LinksConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Links{}, links)
+}
+
+func TestResolve_should_fail_on_unknown_provider(t *testing.T) {
+	_, dir := test_utils.InitRepo(t)
+
+	_, err := Resolve(dir, config.LinksConfig{Provider: "sourceforge"})
+
+	assert.EqualError(t, err, "Unknown provider \"sourceforge\" (expected one of github, gitlab, bitbucket, gitea, azure)")
+}
+
+func addRemote(t *testing.T, repo *git.Repository, url string) {
+	_, err := repo.CreateRemote(&gitConfig.RemoteConfig{Name: DEFAULT_REMOTE, URLs: []string{url}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
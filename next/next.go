@@ -1,15 +1,23 @@
 package next
 
 import (
+	"fmt"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/revlist"
 	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/commit_parser"
 	"github.com/psanetra/git-semver/conventional_commits"
 	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/ignore"
 	"github.com/psanetra/git-semver/latest"
 	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/overrides"
 	"github.com/psanetra/git-semver/semver"
+	"io"
+	"sort"
+	"strings"
 )
 
 type NextOptions struct {
@@ -17,6 +25,20 @@ type NextOptions struct {
 	Stable             bool
 	MajorVersionFilter int
 	PreReleaseOptions  semver.PreReleaseOptions
+	// Commits matching this template (see FormatReleaseCommitMessage) are release commits and are skipped
+	ReleaseCommitMessage string
+	// Explain receives a line for each inspected commit, which explains how the commit affects the next version.
+	// Nothing is written if it is nil.
+	Explain io.Writer
+	// Parser parses the commit messages. commit_parser.Default() is used if it is nil.
+	Parser commit_parser.Parser
+	// Ignore skips matching commits. They are listed separately in the explanation. No commits are ignored if it is nil.
+	Ignore *ignore.Rules
+	// Overrides replace the classification of single commits or exclude them. There are no overrides if it is nil.
+	Overrides *overrides.Overrides
+	// SecurityPatch enforces at least a patch release if there are security fixes (commits of the type "security" or
+	// with CVE or GHSA references in their footers) since the latest version
+	SecurityPatch bool
 }
 
 func Next(options NextOptions) (*semver.Version, error) {
@@ -99,6 +121,11 @@ func Next(options NextOptions) (*semver.Version, error) {
 
 	maxPrioCommitMessage := &conventional_commits.ConventionalCommitMessage{}
 
+	releaseCommitRegex := releaseCommitMessageRegex(options.ReleaseCommitMessage)
+	parser := commit_parser.OrDefault(options.Parser)
+	var ignored []*ignore.Ignored
+	securityFix := false
+
 	for _, hash := range historyDiff {
 		commit, err := repo.CommitObject(hash)
 
@@ -111,13 +138,49 @@ func Next(options NextOptions) (*semver.Version, error) {
 			return nil, errors.WithMessage(err, "Could not read commit "+hash.String())
 		}
 
-		message, err := conventional_commits.ParseCommitMessage(commit.Message)
+		if isReleaseCommit(releaseCommitRegex, commit.Message) {
+			logger.Logger.Debugln("Skipping release commit", commit.Hash.String())
+			explain(options.Explain, commit, "skipped release commit")
+			continue
+		}
+
+		if reason := options.Ignore.Match(commit); reason != "" {
+			logger.Logger.Debugln("Ignoring commit", commit.Hash.String(), "("+reason+")")
+			ignored = append(ignored, &ignore.Ignored{Commit: commit, Reason: reason})
+			continue
+		}
+
+		message, excluded, err := options.Overrides.Classify(commit, parser)
+
+		if excluded {
+			logger.Logger.Debugln("Excluding commit", commit.Hash.String(), "by override")
+			explain(options.Explain, commit, "excluded by override")
+			continue
+		}
 
 		if err != nil {
 			logger.Logger.Debug(err)
+			explain(options.Explain, commit, "no conventional commit: "+describeParseError(err))
 			continue
 		}
 
+		change := commitMessageToSemverChange(message)
+		explanation := changeName(change)
+
+		if options.SecurityPatch && message.IsSecurityFix() {
+			securityFix = true
+
+			if change < semver.FIX {
+				explanation = "security fix"
+			}
+		}
+
+		if options.Overrides.Lookup(commit.Hash) != nil {
+			explanation += " (overridden)"
+		}
+
+		explain(options.Explain, commit, explanation)
+
 		if message.Compare(maxPrioCommitMessage) <= 0 {
 			continue
 		}
@@ -125,15 +188,35 @@ func Next(options NextOptions) (*semver.Version, error) {
 		maxPrioCommitMessage = message
 
 		if message.ContainsBreakingChange {
+			explain(options.Explain, nil, "stopped at the first breaking change")
 			break
 		}
 	}
 
+	if options.Explain != nil && len(ignored) > 0 {
+		// most recent commits first
+		sort.SliceStable(ignored, func(i, j int) bool {
+			return git_utils.ByHistoryDesc{ignored[i].Commit, ignored[j].Commit}.Less(0, 1)
+		})
+
+		explain(options.Explain, nil, "ignored commits:")
+
+		for _, i := range ignored {
+			explain(options.Explain, i.Commit, i.Reason)
+		}
+	}
+
+	change := commitMessageToSemverChange(maxPrioCommitMessage)
+
+	if securityFix && change < semver.FIX {
+		change = semver.FIX
+	}
+
 	nextVersion, err = semver.Increment(
 		*latestReleaseVersion,
 		latestPreReleaseVersion,
 		options.Stable,
-		commitMessageToSemverChange(maxPrioCommitMessage),
+		change,
 		&options.PreReleaseOptions,
 	)
 
@@ -145,6 +228,43 @@ func Next(options NextOptions) (*semver.Version, error) {
 
 }
 
+// Writes "<short hash> <first line> -> <explanation>" or only the explanation if commit is nil.
+func explain(w io.Writer, commit *object.Commit, explanation string) {
+
+	if w == nil {
+		return
+	}
+
+	if commit == nil {
+		_, _ = fmt.Fprintln(w, explanation)
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s %s -> %s\n", commit.Hash.String()[:7], strings.SplitN(commit.Message, "\n", 2)[0], explanation)
+}
+
+func describeParseError(err error) string {
+
+	if parseError, ok := err.(*conventional_commits.ParseError); ok {
+		return fmt.Sprintf("line %d, column %d: %s (%s)", parseError.Line, parseError.Column, parseError.Message, parseError.Rule)
+	}
+
+	return err.Error()
+}
+
+func changeName(change semver.Change) string {
+	switch change {
+	case semver.BREAKING:
+		return "breaking change"
+	case semver.NEW_FEATURE:
+		return "new feature"
+	case semver.FIX:
+		return "fix"
+	}
+
+	return "no release relevant change"
+}
+
 func commitMessageToSemverChange(msg *conventional_commits.ConventionalCommitMessage) semver.Change {
 
 	var semverChange semver.Change
@@ -0,0 +1,220 @@
+package next
+
+import (
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/overrides"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNext_should_skip_release_commits(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "fix(release): 1.0.1")
+
+	version, err := Next(NextOptions{
+		Workdir:              dir,
+		Stable:               true,
+		MajorVersionFilter:   -1,
+		ReleaseCommitMessage: "fix(release): {version}",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", version.ToString())
+}
+
+func TestNext_should_explain_commits(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	fix := test_utils.Commit(t, repo, "fix:Fix")
+
+	parser, err := commit_parser.New(config.ParserConfig{Mode: "strict"})
+	assert.Nil(t, err)
+
+	var explanation strings.Builder
+
+	version, err := Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		Explain:            &explanation,
+		Parser:             parser,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", version.ToString())
+	assert.Equal(t, fix.String()[:7]+" fix:Fix -> no conventional commit: line 1, column 4: expected \": \" after the type, scope and breaking change indicator (header-separator)\n", explanation.String())
+}
+
+func TestNext_should_use_parser(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, ":sparkles: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, ":sparkles: Add another feature")
+
+	parser, err := commit_parser.New(config.ParserConfig{Preset: "gitmoji"})
+	assert.Nil(t, err)
+
+	version, err := Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		Parser:             parser,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0", version.ToString())
+}
+
+func TestNext_should_ignore_commits(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	fix := test_utils.Commit(t, repo, "fix: Fix bug")
+	fixup := test_utils.Commit(t, repo, "fixup! feat!: Replace feature")
+	feature := test_utils.Commit(t, repo, "feat: Add another feature\n\nRelease-Note: none")
+
+	rules, err := ignore.NewRules(&config.IgnoreConfig{Footers: []string{"Release-Note: none"}})
+	assert.Nil(t, err)
+
+	var explanation strings.Builder
+
+	version, err := Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		Explain:            &explanation,
+		Ignore:             rules,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version.ToString())
+	assert.Equal(t, fix.String()[:7]+" fix: Fix bug -> fix\n"+
+		"ignored commits:\n"+
+		feature.String()[:7]+" feat: Add another feature -> footer \"Release-Note: none\"\n"+
+		fixup.String()[:7]+" fixup! feat!: Replace feature -> message matches ^(fixup|squash|amend)! \n", explanation.String())
+}
+
+func TestNext_should_apply_overrides(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	mistyped := test_utils.Commit(t, repo, "feat: Fix bug")
+	excluded := test_utils.Commit(t, repo, "feat!: Replace feature")
+
+	commitOverrides, err := overrides.New(map[string]*overrides.Override{
+		mistyped.String(): {Type: "fix"},
+		excluded.String(): {Exclude: true},
+	})
+	assert.Nil(t, err)
+
+	var explanation strings.Builder
+
+	version, err := Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		Explain:            &explanation,
+		Overrides:          commitOverrides,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version.ToString())
+	assert.Contains(t, explanation.String(), mistyped.String()[:7]+" feat: Fix bug -> fix (overridden)\n")
+	assert.Contains(t, explanation.String(), excluded.String()[:7]+" feat!: Replace feature -> excluded by override\n")
+}
+
+func TestNext_should_enforce_patch_release_for_security_fixes(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	security := test_utils.Commit(t, repo, "security: Escape input")
+	bump := test_utils.Commit(t, repo, "chore(deps): Bump yaml\n\nRefs: CVE-2024-12345")
+
+	version, err := Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", version.ToString())
+
+	var explanation strings.Builder
+
+	version, err = Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		Explain:            &explanation,
+		SecurityPatch:      true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version.ToString())
+	assert.Contains(t, explanation.String(), security.String()[:7]+" security: Escape input -> security fix\n")
+	assert.Contains(t, explanation.String(), bump.String()[:7]+" chore(deps): Bump yaml -> security fix\n")
+
+	test_utils.Commit(t, repo, "feat: Add endpoint")
+
+	version, err = Next(NextOptions{
+		Workdir:            dir,
+		Stable:             true,
+		MajorVersionFilter: -1,
+		SecurityPatch:      true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0", version.ToString())
+}
+
+func TestAssertSecurityFixesReleased_should_fail_if_security_fixes_are_unreleased_for_too_many_commits(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	security := test_utils.Commit(t, repo, "fix(deps): Bump yaml\n\nRefs: GHSA-8r8j-xvfj-36f9")
+	test_utils.Commit(t, repo, "feat: Add endpoint")
+	test_utils.Commit(t, repo, "fix: Fix endpoint")
+
+	options := NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1}
+
+	err := AssertSecurityFixesReleased(options, 1)
+
+	assert.EqualError(t, err, "Security fix "+security.String()[:7]+" is unreleased for 2 commits (maximum 1)")
+
+	assert.Nil(t, AssertSecurityFixesReleased(options, 2))
+	assert.Nil(t, AssertSecurityFixesReleased(options, 0))
+}
+
+func TestAssertSecurityFixesReleased_should_count_commits_since_preceding_version(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	security := test_utils.Commit(t, repo, "fix(deps): Bump yaml\n\nRefs: GHSA-8r8j-xvfj-36f9")
+	test_utils.Tag(t, repo, "v1.0.1-rc.1")
+	test_utils.Commit(t, repo, "feat: Add endpoint")
+	test_utils.Commit(t, repo, "fix: Fix endpoint")
+
+	options := NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1, PreReleaseOptions: semver.PreReleaseOptions{Label: "rc"}}
+
+	assert.Nil(t, AssertSecurityFixesReleased(options, 1))
+
+	options.PreReleaseOptions = semver.PreReleaseOptions{}
+
+	assert.EqualError(t, AssertSecurityFixesReleased(options, 1), "Security fix "+security.String()[:7]+" is unreleased for 2 commits (maximum 1)")
+}
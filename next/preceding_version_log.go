@@ -0,0 +1,30 @@
+package next
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/latest"
+	"github.com/psanetra/git-semver/version_log"
+)
+
+// Returns the tag of the version preceding the next version and the commits since this tag without the ignored commits.
+// The tag is found like the latest version of the next version: Only tags of options.MajorVersionFilter are considered
+// and pre-releases only precede pre-releases. The tag is nil if there is no preceding version.
+func PrecedingVersionLog(repo *git.Repository, options NextOptions) (*plumbing.Reference, []*object.Commit, error) {
+
+	_, precedingTag, err := latest.FindLatestVersion(repo, options.MajorVersionFilter, options.PreReleaseOptions.ShouldBePreRelease())
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not find latest version")
+	}
+
+	commits, err := version_log.CommitsSince(repo, precedingTag, options.Ignore)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return precedingTag, commits, nil
+}
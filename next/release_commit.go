@@ -0,0 +1,43 @@
+package next
+
+import (
+	"regexp"
+	"strings"
+)
+
+const VERSION_PLACEHOLDER = "{version}"
+
+const DEFAULT_RELEASE_COMMIT_MESSAGE = "chore(release): " + VERSION_PLACEHOLDER
+
+// Replaces the {version} placeholder in a release commit message template.
+func FormatReleaseCommitMessage(template string, version string) string {
+	return strings.ReplaceAll(template, VERSION_PLACEHOLDER, version)
+}
+
+// Returns a regex, which matches the first line of release commit messages created with the template.
+// Returns nil if the template is empty.
+func releaseCommitMessageRegex(template string) *regexp.Regexp {
+
+	template = strings.TrimSpace(firstLine(template))
+
+	if template == "" {
+		return nil
+	}
+
+	parts := strings.Split(template, VERSION_PLACEHOLDER)
+
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile(`^` + strings.Join(parts, `v?\d+\.\d+\.\d+\S*`) + `$`)
+}
+
+func isReleaseCommit(regex *regexp.Regexp, message string) bool {
+	return regex != nil && regex.MatchString(strings.TrimSpace(firstLine(strings.TrimSpace(message))))
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return line
+}
@@ -0,0 +1,23 @@
+package next
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_isReleaseCommit_should_match_release_commits_created_with_template(t *testing.T) {
+	regex := releaseCommitMessageRegex(DEFAULT_RELEASE_COMMIT_MESSAGE)
+
+	assert.True(t, isReleaseCommit(regex, "chore(release): 1.2.3"))
+	assert.True(t, isReleaseCommit(regex, "chore(release): 1.2.3-beta.1\n\nSome body"))
+	assert.False(t, isReleaseCommit(regex, "chore(release): Update release scripts"))
+	assert.False(t, isReleaseCommit(regex, "feat: 1.2.3"))
+}
+
+func Test_isReleaseCommit_should_not_match_anything_without_template(t *testing.T) {
+	assert.False(t, isReleaseCommit(releaseCommitMessageRegex(""), "chore(release): 1.2.3"))
+}
+
+func TestFormatReleaseCommitMessage_should_replace_version_placeholder(t *testing.T) {
+	assert.Equal(t, "release: v1.2.3", FormatReleaseCommitMessage("release: v{version}", "1.2.3"))
+}
@@ -0,0 +1,42 @@
+package next
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+)
+
+// Returns an error if a security fix since the preceding version (see PrecedingVersionLog) is followed by more than
+// maxUnreleasedCommits commits, e.g. to detect overdue security releases. Commits excluded by overrides are no security
+// fixes. 0 disables the check.
+func AssertSecurityFixesReleased(options NextOptions, maxUnreleasedCommits int) error {
+
+	if maxUnreleasedCommits <= 0 {
+		return nil
+	}
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not open git repository")
+	}
+
+	_, commits, err := PrecedingVersionLog(repo, options)
+
+	if err != nil {
+		return err
+	}
+
+	// commits are ordered from the most recent one, so commits[i] is followed by i commits
+	for i := len(commits) - 1; i > maxUnreleasedCommits; i-- {
+		commit := commits[i]
+		message, excluded, err := options.Overrides.Classify(commit, options.Parser)
+
+		if !excluded && err == nil && message.IsSecurityFix() {
+			return errors.Errorf("Security fix %s is unreleased for %d commits (maximum %d)", commit.Hash.String()[:7], i, maxUnreleasedCommits)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package overrides
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DEFAULT_FILE is the name of the overrides file in the root of the repository
+const DEFAULT_FILE = ".git-semver-overrides.yaml"
+
+// DEFAULT_NOTES_REF is the ref of the git notes, which contain overrides
+const DEFAULT_NOTES_REF = "refs/notes/semver"
+
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+var changeTypeRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// Override replaces the classification of a commit. Empty fields keep the parsed values.
+type Override struct {
+	// Type replaces the change type. It also classifies commits, which are no conventional commits.
+	Type string `yaml:"type,omitempty"`
+	// Scope replaces the scope. An empty string removes the scope.
+	Scope *string `yaml:"scope,omitempty"`
+	// Breaking replaces the breaking change flag. false also removes the BREAKING CHANGE footers.
+	Breaking *bool `yaml:"breaking,omitempty"`
+	// Description replaces the description
+	Description string `yaml:"description,omitempty"`
+	// Exclude removes the commit from the calculation of the next version and from the release notes
+	Exclude bool `yaml:"exclude,omitempty"`
+}
+
+// Overrides contain the overrides keyed by commit hash. A nil *Overrides contains no overrides.
+type Overrides struct {
+	// byHash contains full hashes and hash prefixes
+	byHash map[string]*Override
+}
+
+// Creates overrides keyed by commit hashes or unique hash prefixes with at least 4 characters.
+func New(overrides map[string]*Override) (*Overrides, error) {
+
+	ret := &Overrides{byHash: make(map[string]*Override)}
+
+	for hash, override := range overrides {
+		if err := ret.add(hash, override); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (o *Overrides) add(hash string, override *Override) error {
+
+	hash = strings.ToLower(strings.TrimSpace(hash))
+
+	if !commitHashRegex.MatchString(hash) {
+		return errors.Errorf("Invalid override commit \"%s\" (expected a hash with at least 4 characters)", hash)
+	}
+
+	if override == nil {
+		return errors.Errorf("Empty override of commit %s", hash)
+	}
+
+	if override.Type != "" && !changeTypeRegex.MatchString(override.Type) {
+		return errors.Errorf("Invalid change type \"%s\" in override of commit %s", override.Type, hash)
+	}
+
+	o.byHash[hash] = override
+
+	return nil
+}
+
+// Parses the content of an overrides file. It maps commit hashes to overrides:
+//
+//	4d1e0b7:
+//	  type: fix
+func Parse(content []byte) (*Overrides, error) {
+
+	overrides := make(map[string]*Override)
+
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, errors.WithMessage(err, "Could not parse overrides")
+	}
+
+	return New(overrides)
+}
+
+// Loads the overrides of the notes ref and of the overrides file of the repository in workdir. Overrides of the file
+// replace notes of the same commit. Missing default files and notes refs are skipped.
+func Load(workdir string, cfg config.OverridesConfig) (*Overrides, error) {
+
+	repo, err := git.PlainOpenWithOptions(workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	notesRef := cfg.NotesRef
+
+	if notesRef == "" {
+		notesRef = DEFAULT_NOTES_REF
+	}
+
+	overrides, err := loadNotes(repo, plumbing.ReferenceName(notesRef))
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := config.RepositoryRoot(workdir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file := cfg.File
+
+	if file == "" {
+		file = DEFAULT_FILE
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, file))
+
+	if os.IsNotExist(err) && cfg.File == "" {
+		return overrides, nil
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "Could not read overrides file")
+	}
+
+	fileOverrides, err := Parse(content)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, file)
+	}
+
+	for hash, override := range fileOverrides.byHash {
+		// notes are keyed by full hashes, the file may contain hash prefixes
+		for noteHash := range overrides.byHash {
+			if strings.HasPrefix(noteHash, hash) {
+				delete(overrides.byHash, noteHash)
+			}
+		}
+
+		overrides.byHash[hash] = override
+	}
+
+	return overrides, nil
+}
+
+// Returns the overrides of the notes in notesRef. The note of a commit contains a single override in yaml.
+func loadNotes(repo *git.Repository, notesRef plumbing.ReferenceName) (*Overrides, error) {
+
+	overrides := &Overrides{byHash: make(map[string]*Override)}
+
+	ref, err := repo.Reference(notesRef, true)
+
+	if err == plumbing.ErrReferenceNotFound {
+		return overrides, nil
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "Could not resolve "+notesRef.String())
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read notes commit of "+notesRef.String())
+	}
+
+	files, err := commit.Files()
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read notes of "+notesRef.String())
+	}
+
+	err = files.ForEach(func(file *object.File) error {
+		// notes may be stored in fanout directories (e.g. "4d/1e0b7...")
+		hash := strings.ReplaceAll(file.Name, "/", "")
+
+		content, err := file.Contents()
+
+		if err != nil {
+			return errors.WithMessage(err, "Could not read note of commit "+hash)
+		}
+
+		override := &Override{}
+
+		if err = yaml.Unmarshal([]byte(content), override); err != nil {
+			return errors.WithMessage(err, "Could not parse note of commit "+hash)
+		}
+
+		return overrides.add(hash, override)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// Returns the override of the commit or nil if there is none.
+func (o *Overrides) Lookup(hash plumbing.Hash) *Override {
+
+	if o == nil {
+		return nil
+	}
+
+	str := hash.String()
+
+	if override, ok := o.byHash[str]; ok {
+		return override
+	}
+
+	for prefix, override := range o.byHash {
+		if strings.HasPrefix(str, prefix) {
+			return override
+		}
+	}
+
+	return nil
+}
+
+// Applies the override to the parsed commit message. parsed and parseErr are the result of parsing message. The parse
+// error is returned if the override does not set a type. Otherwise the first line of message becomes the description.
+func (o *Override) Apply(message string, parsed *conventional_commits.ConventionalCommitMessage, parseErr error) (*conventional_commits.ConventionalCommitMessage, error) {
+
+	if parseErr != nil {
+		if o.Type == "" {
+			return nil, parseErr
+		}
+
+		firstLine, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+		parsed = &conventional_commits.ConventionalCommitMessage{Description: strings.TrimSpace(firstLine), Body: strings.TrimSpace(body)}
+	}
+
+	ret := *parsed
+	ret.Footers = make(map[string][]string)
+
+	for token, values := range parsed.Footers {
+		if o.Breaking != nil && !*o.Breaking && conventional_commits.IsBreakingChangeToken(token) {
+			continue
+		}
+
+		ret.Footers[token] = values
+	}
+
+	if o.Type != "" {
+		ret.ChangeType = conventional_commits.ChangeType(strings.ToLower(o.Type))
+	}
+
+	if o.Scope != nil {
+		ret.Scope = *o.Scope
+	}
+
+	if o.Breaking != nil {
+		ret.ContainsBreakingChange = *o.Breaking
+	}
+
+	if o.Description != "" {
+		ret.Description = o.Description
+	}
+
+	return &ret, nil
+}
+
+// Parses the message of the commit with parser (or commit_parser.Default() if it is nil) and applies the override of
+// the commit. excluded is true if the override excludes the commit. The message is not parsed then.
+func (o *Overrides) Classify(commit *object.Commit, parser commit_parser.Parser) (message *conventional_commits.ConventionalCommitMessage, excluded bool, err error) {
+
+	override := o.Lookup(commit.Hash)
+
+	if override != nil && override.Exclude {
+		return nil, true, nil
+	}
+
+	message, err = commit_parser.OrDefault(parser).Parse(commit.Message)
+
+	if override != nil {
+		message, err = override.Apply(commit.Message, message, err)
+	}
+
+	return message, false, err
+}
@@ -0,0 +1,121 @@
+package overrides
+
+import (
+	"errors"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse_should_parse_overrides(t *testing.T) {
+	overrides, err := Parse([]byte(`
+4d1e0b7:
+  type: fix
+  scope: ""
+0123456789abcdef0123456789abcdef01234567:
+  exclude: true
+`))
+
+	assert.Nil(t, err)
+
+	empty := ""
+	assert.Equal(t, &Override{Type: "fix", Scope: &empty}, overrides.Lookup(plumbing.NewHash("4d1e0b7f0123456789abcdef0123456789abcdef")))
+	assert.Equal(t, &Override{Exclude: true}, overrides.Lookup(plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")))
+	assert.Nil(t, overrides.Lookup(plumbing.NewHash("f123456789abcdef0123456789abcdef01234567")))
+}
+
+func TestParse_should_fail_on_invalid_overrides(t *testing.T) {
+	_, err := Parse([]byte("abc:\n  type: fix\n"))
+	assert.EqualError(t, err, "Invalid override commit \"abc\" (expected a hash with at least 4 characters)")
+
+	_, err = Parse([]byte("4d1e0b7:\n  type: fix!\n"))
+	assert.EqualError(t, err, "Invalid change type \"fix!\" in override of commit 4d1e0b7")
+
+	_, err = Parse([]byte("4d1e0b7:\n"))
+	assert.EqualError(t, err, "Empty override of commit 4d1e0b7")
+}
+
+func TestOverrides_should_be_empty_if_nil(t *testing.T) {
+	var overrides *Overrides
+
+	assert.Nil(t, overrides.Lookup(plumbing.NewHash("4d1e0b7f0123456789abcdef0123456789abcdef")))
+}
+
+func TestOverride_Apply_should_replace_fields(t *testing.T) {
+	message := "feat(api)!: Add handler\n\nBREAKING CHANGE: Removes the old handler\nRefs: #12"
+	parsed, err := conventional_commits.ParseCommitMessage(message)
+	assert.Nil(t, err)
+
+	breaking := false
+	scope := "core"
+
+	result, err := (&Override{Type: "Fix", Scope: &scope, Breaking: &breaking, Description: "Fix handler"}).Apply(message, parsed, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &conventional_commits.ConventionalCommitMessage{
+		ChangeType:  conventional_commits.FIX,
+		Scope:       "core",
+		Description: "Fix handler",
+		Footers:     map[string][]string{"Refs": {"#12"}},
+	}, result)
+	assert.True(t, parsed.ContainsBreakingChange, "the parsed message should not be changed")
+
+	unchanged, err := (&Override{}).Apply(message, parsed, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, parsed, unchanged)
+}
+
+func TestOverride_Apply_should_classify_non_conventional_commits(t *testing.T) {
+	parseErr := errors.New("no conventional commit")
+
+	result, err := (&Override{Type: "fix"}).Apply("Fix handler\n\nDetails", nil, parseErr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &conventional_commits.ConventionalCommitMessage{
+		ChangeType:  conventional_commits.FIX,
+		Description: "Fix handler",
+		Body:        "Details",
+		Footers:     map[string][]string{},
+	}, result)
+
+	_, err = (&Override{Description: "Fix handler"}).Apply("Fix handler", nil, parseErr)
+
+	assert.Equal(t, parseErr, err)
+}
+
+func TestLoad_should_load_notes_and_file(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	first := test_utils.Commit(t, repo, "feat: Fix handler")
+	second := test_utils.Commit(t, repo, "fix: Add endpoint")
+	test_utils.Note(t, repo, DEFAULT_NOTES_REF, first, "type: fix\n")
+	test_utils.Note(t, repo, DEFAULT_NOTES_REF, second, "type: feat\n")
+	test_utils.WriteFile(t, repo, DEFAULT_FILE, second.String()[:7]+":\n  exclude: true\n")
+
+	overrides, err := Load(dir, config.OverridesConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Override{Type: "fix"}, overrides.Lookup(first))
+	assert.Equal(t, &Override{Exclude: true}, overrides.Lookup(second))
+}
+
+func TestLoad_should_use_configured_sources(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	commit := test_utils.Commit(t, repo, "feat: Fix handler")
+	test_utils.Note(t, repo, DEFAULT_NOTES_REF, commit, "type: feat\n")
+	test_utils.Note(t, repo, "refs/notes/release", commit, "type: fix\n")
+
+	overrides, err := Load(dir, config.OverridesConfig{NotesRef: "refs/notes/release"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Override{Type: "fix"}, overrides.Lookup(commit))
+
+	_, err = Load(dir, config.OverridesConfig{File: "missing.yaml"})
+
+	assert.ErrorContains(t, err, "Could not read overrides file")
+}
@@ -0,0 +1,243 @@
+package pretty
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/overrides"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Kind string
+
+const (
+	// ONELINE prints "<hash> <subject>"
+	ONELINE Kind = "oneline"
+	// SHORT prints the hash, the author and the subject
+	SHORT Kind = "short"
+	// FULL prints the hash, the author, the committer and the whole commit message
+	FULL Kind = "full"
+	// FORMAT prints the commits with a format string containing placeholders (e.g. "format:%h %s")
+	FORMAT Kind = "format"
+)
+
+// GIT_DATE_LAYOUT is the default date format of git
+const GIT_DATE_LAYOUT = "Mon Jan 2 15:04:05 2006 -0700"
+
+// Pretty formats commits like "git log --pretty"
+type Pretty struct {
+	Kind Kind
+	// Spec is the format string of FORMAT
+	Spec string
+	// Parser parses the commit messages for the conventional commit placeholders. commit_parser.Default() is used if
+	// it is nil.
+	Parser commit_parser.Parser
+	// Overrides replace the classification of single commits for the conventional commit placeholders. There are no
+	// overrides if it is nil.
+	Overrides *overrides.Overrides
+}
+
+// Parses oneline | short | full | format:<spec>.
+func Parse(pretty string) (*Pretty, error) {
+
+	if spec, ok := strings.CutPrefix(pretty, string(FORMAT)+":"); ok {
+		return &Pretty{Kind: FORMAT, Spec: spec}, nil
+	}
+
+	switch Kind(pretty) {
+	case ONELINE, SHORT, FULL:
+		return &Pretty{Kind: Kind(pretty)}, nil
+	}
+
+	return nil, errors.Errorf("Unknown pretty format \"%s\" (expected one of %s, %s, %s or %s:<spec>)", pretty, ONELINE, SHORT, FULL, FORMAT)
+}
+
+// Formats the commits. Each commit of ONELINE and FORMAT is terminated by a newline. The commits of SHORT and FULL are
+// separated by an empty line.
+func (p *Pretty) Format(commits []*object.Commit) string {
+
+	var builder strings.Builder
+
+	for i, commit := range commits {
+		switch p.Kind {
+		case ONELINE:
+			builder.WriteString(commit.Hash.String() + " " + subject(commit.Message) + "\n")
+		case FORMAT:
+			builder.WriteString(Expand(p.Spec, commit, p.Parser, p.Overrides) + "\n")
+		case SHORT, FULL:
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+
+			builder.WriteString("commit " + commit.Hash.String() + "\n")
+			builder.WriteString("Author: " + commit.Author.Name + " <" + commit.Author.Email + ">\n")
+
+			message := subject(commit.Message)
+
+			if p.Kind == FULL {
+				builder.WriteString("Commit: " + commit.Committer.Name + " <" + commit.Committer.Email + ">\n")
+				message = strings.TrimRight(commit.Message, "\n")
+			}
+
+			builder.WriteString("\n")
+
+			for _, line := range strings.Split(message, "\n") {
+				builder.WriteString(strings.TrimRight("    "+line, " ") + "\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// Replaces the placeholders in spec with the fields of the commit. Unknown placeholders are not replaced.
+//
+// git placeholders: %H (hash), %h (short hash), %an, %ae, %ad, %as, %aI, %at (author name, email and date in git,
+// short, ISO 8601 and unix format), %cn, %ce, %cd, %cs, %cI, %ct (committer), %s (subject), %b (body), %B (raw
+// message), %n (newline), %% (percent sign)
+//
+// conventional commit placeholders: %(type), %(scope), %(breaking) ("!" for breaking changes) and %(description). They
+// are empty if the commit message is no conventional commit message or if the commit is excluded by an override. The
+// message is parsed with parser or with commit_parser.Default() if it is nil. The override of the commit is applied.
+func Expand(spec string, commit *object.Commit, parser commit_parser.Parser, commitOverrides *overrides.Overrides) string {
+
+	var builder strings.Builder
+	var message *conventional_commits.ConventionalCommitMessage
+	parsed := false
+
+	conventional := func() *conventional_commits.ConventionalCommitMessage {
+		if !parsed {
+			parsed = true
+			message, _, _ = commitOverrides.Classify(commit, parser)
+		}
+
+		return message
+	}
+
+	for i := 0; i < len(spec); i++ {
+		if spec[i] != '%' || i == len(spec)-1 {
+			builder.WriteByte(spec[i])
+			continue
+		}
+
+		rest := spec[i+1:]
+
+		if strings.HasPrefix(rest, "(") {
+			if end := strings.Index(rest, ")"); end >= 0 {
+				if value, ok := conventionalField(rest[1:end], conventional()); ok {
+					builder.WriteString(value)
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		if value, length, ok := gitField(rest, commit); ok {
+			builder.WriteString(value)
+			i += length
+			continue
+		}
+
+		builder.WriteByte('%')
+	}
+
+	return builder.String()
+}
+
+func conventionalField(name string, message *conventional_commits.ConventionalCommitMessage) (string, bool) {
+
+	switch name {
+	case "type", "scope", "breaking", "description":
+	default:
+		return "", false
+	}
+
+	if message == nil {
+		return "", true
+	}
+
+	switch name {
+	case "type":
+		return string(message.ChangeType), true
+	case "scope":
+		return message.Scope, true
+	case "breaking":
+		if message.ContainsBreakingChange {
+			return "!", true
+		}
+
+		return "", true
+	}
+
+	return message.Description, true
+}
+
+// Returns the value of the git placeholder at the beginning of str (without "%") and the length of the placeholder.
+func gitField(str string, commit *object.Commit) (string, int, bool) {
+
+	switch str[0] {
+	case 'H':
+		return commit.Hash.String(), 1, true
+	case 'h':
+		return commit.Hash.String()[:7], 1, true
+	case 's':
+		return subject(commit.Message), 1, true
+	case 'b':
+		return body(commit.Message), 1, true
+	case 'B':
+		return commit.Message, 1, true
+	case 'n':
+		return "\n", 1, true
+	case '%':
+		return "%", 1, true
+	}
+
+	if len(str) < 2 {
+		return "", 0, false
+	}
+
+	var signature object.Signature
+
+	switch str[0] {
+	case 'a':
+		signature = commit.Author
+	case 'c':
+		signature = commit.Committer
+	default:
+		return "", 0, false
+	}
+
+	switch str[1] {
+	case 'n':
+		return signature.Name, 2, true
+	case 'e':
+		return signature.Email, 2, true
+	case 'd':
+		return signature.When.Format(GIT_DATE_LAYOUT), 2, true
+	case 's':
+		return signature.When.Format(time.DateOnly), 2, true
+	case 'I':
+		return signature.When.Format(time.RFC3339), 2, true
+	case 't':
+		return strconv.FormatInt(signature.When.Unix(), 10), 2, true
+	}
+
+	return "", 0, false
+}
+
+// Returns the first paragraph of the message joined to a single line like git.
+func subject(message string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimLeft(message, "\n"), "\n\n")
+
+	return strings.Join(strings.Fields(strings.ReplaceAll(paragraph, "\n", " ")), " ")
+}
+
+// Returns the message without subject.
+func body(message string) string {
+	_, rest, _ := strings.Cut(strings.TrimLeft(message, "\n"), "\n\n")
+
+	return strings.Trim(rest, "\n")
+}
@@ -0,0 +1,101 @@
+package pretty
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/overrides"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var hash = plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")
+
+func testCommit(message string) *object.Commit {
+	when := time.Date(2020, 6, 3, 20, 17, 23, 0, time.UTC)
+
+	return &object.Commit{
+		Hash:      hash,
+		Author:    object.Signature{Name: "Jane", Email: "jane@example.com", When: when},
+		Committer: object.Signature{Name: "John", Email: "john@example.com", When: when.Add(time.Hour)},
+		Message:   message,
+	}
+}
+
+func TestParse_should_parse_formats(t *testing.T) {
+	pretty, err := Parse("oneline")
+	assert.Nil(t, err)
+	assert.Equal(t, &Pretty{Kind: ONELINE}, pretty)
+
+	pretty, err = Parse("format:%h:%s")
+	assert.Nil(t, err)
+	assert.Equal(t, &Pretty{Kind: FORMAT, Spec: "%h:%s"}, pretty)
+
+	_, err = Parse("medium")
+	assert.EqualError(t, err, "Unknown pretty format \"medium\" (expected one of oneline, short, full or format:<spec>)")
+}
+
+func TestExpand_should_replace_git_placeholders(t *testing.T) {
+	commit := testCommit("feat: Add\nfeature\n\nBody line\n")
+
+	result := Expand("%H|%h|%an <%ae>|%ad|%as|%aI|%at|%cn <%ce>|%cs|%cI|%s|%b|%n|%%|%x|%", commit, nil, nil)
+
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567|0123456|Jane <jane@example.com>|Wed Jun 3 20:17:23 2020 +0000|2020-06-03|2020-06-03T20:17:23Z|1591215443|John <john@example.com>|2020-06-03|2020-06-03T21:17:23Z|feat: Add feature|Body line|\n|%|%x|%", result)
+}
+
+func TestExpand_should_replace_conventional_commit_placeholders(t *testing.T) {
+	assert.Equal(t, "feat|api|!|Add endpoint|%(unknown)", Expand("%(type)|%(scope)|%(breaking)|%(description)|%(unknown)", testCommit("feat(api)!: Add endpoint"), nil, nil))
+	assert.Equal(t, "fix|||Fix", Expand("%(type)|%(scope)|%(breaking)|%(description)", testCommit("fix: Fix"), nil, nil))
+	assert.Equal(t, "|||", Expand("%(type)|%(scope)|%(breaking)|%(description)", testCommit("Non-conventional commit"), nil, nil))
+}
+
+func TestExpand_should_apply_overrides(t *testing.T) {
+	commit := testCommit("Add endpoint")
+
+	commitOverrides, err := overrides.New(map[string]*overrides.Override{commit.Hash.String(): {Type: "feat"}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "feat|Add endpoint", Expand("%(type)|%(description)", commit, nil, commitOverrides))
+}
+
+func TestFormat_should_format_oneline(t *testing.T) {
+	pretty := &Pretty{Kind: ONELINE}
+
+	assert.Equal(t, hash.String()+" fix: Fix\n"+hash.String()+" feat: Add\n", pretty.Format([]*object.Commit{testCommit("fix: Fix\n\nBody"), testCommit("feat: Add")}))
+}
+
+func TestFormat_should_format_short_and_full(t *testing.T) {
+	commits := []*object.Commit{testCommit("fix: Fix\n\nBody\n"), testCommit("feat: Add")}
+
+	assert.Equal(t, `commit `+hash.String()+`
+Author: Jane <jane@example.com>
+
+    fix: Fix
+
+commit `+hash.String()+`
+Author: Jane <jane@example.com>
+
+    feat: Add
+`, (&Pretty{Kind: SHORT}).Format(commits))
+
+	assert.Equal(t, `commit `+hash.String()+`
+Author: Jane <jane@example.com>
+Commit: John <john@example.com>
+
+    fix: Fix
+
+    Body
+
+commit `+hash.String()+`
+Author: Jane <jane@example.com>
+Commit: John <john@example.com>
+
+    feat: Add
+`, (&Pretty{Kind: FULL}).Format(commits))
+}
+
+func TestFormat_should_terminate_format_entries(t *testing.T) {
+	pretty := &Pretty{Kind: FORMAT, Spec: "%(type):%(description)"}
+
+	assert.Equal(t, "fix:Fix\nfeat:Add\n", pretty.Format([]*object.Commit{testCommit("fix: Fix"), testCommit("feat: Add")}))
+}
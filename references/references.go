@@ -0,0 +1,252 @@
+package references
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Type string
+
+const (
+	ISSUE        Type = "issue"
+	PULL_REQUEST Type = "pull_request"
+)
+
+// Source is the part of the commit message, which contains a reference
+type Source string
+
+const (
+	DESCRIPTION Source = "description"
+	BODY        Source = "body"
+	FOOTER      Source = "footer"
+)
+
+// Reference is a reference to an issue or a pull request
+type Reference struct {
+	Type Type `json:"type"`
+	// ID identifies the issue or pull request (e.g. "123" for "#123" or "PROJ-123")
+	ID string `json:"id"`
+	// Text is the reference like it is written in the commit message (e.g. "#123")
+	Text   string `json:"text"`
+	Source Source `json:"source"`
+	// URL is empty if there are no links
+	URL string `json:"url,omitempty"`
+	// urlTemplate is the url template of the pattern, which matched the reference
+	urlTemplate string
+}
+
+// builtInPatterns can be referenced by name in the configuration
+var builtInPatterns = map[string]config.ReferencePattern{
+	// GitHub, Gitea and Bitbucket issues and pull requests
+	"github": {Regex: `#(\d+)`, Type: string(ISSUE)},
+	// GitLab merge requests
+	"gitlab": {Regex: `!(\d+)`, Type: string(PULL_REQUEST)},
+	// Jira issue keys
+	"jira": {Regex: `[A-Z][A-Z0-9_]+-\d+`, Type: string(ISSUE)},
+}
+
+// DEFAULT_PATTERNS are used if no patterns are configured
+var DEFAULT_PATTERNS = []string{"github", "gitlab"}
+
+// numericFooterValueRegex matches the values of footers like "Closes #123", which the lenient parser parses without "#"
+var numericFooterValueRegex = regexp.MustCompile(`^\d+$`)
+
+type pattern struct {
+	regex       *regexp.Regexp
+	refType     Type
+	urlTemplate string
+}
+
+// Parser extracts references from commit messages
+type Parser struct {
+	patterns []*pattern
+}
+
+// Creates a parser for the configured patterns or for DEFAULT_PATTERNS if no patterns are configured.
+func NewParser(cfg config.ReferencesConfig) (*Parser, error) {
+
+	patternConfigs := cfg.Patterns
+
+	if len(patternConfigs) == 0 {
+		for _, name := range DEFAULT_PATTERNS {
+			patternConfigs = append(patternConfigs, config.ReferencePattern{Name: name})
+		}
+	}
+
+	parser := &Parser{}
+
+	for _, patternConfig := range patternConfigs {
+		p, err := newPattern(patternConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		parser.patterns = append(parser.patterns, p)
+	}
+
+	return parser, nil
+}
+
+// Returns a parser for DEFAULT_PATTERNS.
+func DefaultParser() *Parser {
+	parser, err := NewParser(config.ReferencesConfig{})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return parser
+}
+
+func newPattern(patternConfig config.ReferencePattern) (*pattern, error) {
+
+	if builtIn, ok := builtInPatterns[patternConfig.Name]; ok {
+		if patternConfig.Regex == "" {
+			patternConfig.Regex = builtIn.Regex
+		}
+
+		if patternConfig.Type == "" {
+			patternConfig.Type = builtIn.Type
+		}
+	}
+
+	if patternConfig.Regex == "" {
+		return nil, errors.Errorf("Reference pattern \"%s\" is no built-in pattern and has no regex", patternConfig.Name)
+	}
+
+	refType := ISSUE
+
+	switch Type(patternConfig.Type) {
+	case "", ISSUE:
+	case PULL_REQUEST:
+		refType = PULL_REQUEST
+	default:
+		return nil, errors.Errorf("Unknown reference type \"%s\" (expected %s or %s)", patternConfig.Type, ISSUE, PULL_REQUEST)
+	}
+
+	// references must not be preceded by word characters and must not be part of urls or html entities
+	regex, err := regexp.Compile(`(?:^|[^\w&/])(?P<text>` + patternConfig.Regex + `)\b`)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not parse reference pattern "+patternConfig.Regex)
+	}
+
+	return &pattern{
+		regex:       regex,
+		refType:     refType,
+		urlTemplate: patternConfig.URL,
+	}, nil
+}
+
+// Returns the distinct references in the description, the body and the footers of the message ordered by their first
+// occurrence.
+func (p *Parser) Parse(message *conventional_commits.ConventionalCommitMessage) []*Reference {
+
+	var references []*Reference
+	seen := make(map[string]bool)
+
+	add := func(str string, source Source) {
+		for _, reference := range p.find(str, source) {
+			if !seen[reference.Text] {
+				seen[reference.Text] = true
+				references = append(references, reference)
+			}
+		}
+	}
+
+	add(message.Description, DESCRIPTION)
+	add(message.Body, BODY)
+
+	var tokens []string
+
+	for token := range message.Footers {
+		tokens = append(tokens, token)
+	}
+
+	sort.Strings(tokens)
+
+	for _, token := range tokens {
+		for _, value := range message.Footers[token] {
+			if numericFooterValueRegex.MatchString(value) {
+				value = "#" + value
+			}
+
+			add(value, FOOTER)
+		}
+	}
+
+	return references
+}
+
+func (p *Parser) find(str string, source Source) []*Reference {
+
+	type match struct {
+		index     int
+		reference *Reference
+	}
+
+	var matches []match
+
+	for _, pattern := range p.patterns {
+		textIndex := pattern.regex.SubexpIndex("text")
+
+		for _, submatch := range pattern.regex.FindAllStringSubmatchIndex(str, -1) {
+			text := str[submatch[2*textIndex]:submatch[2*textIndex+1]]
+
+			matches = append(matches, match{submatch[2*textIndex], &Reference{
+				Type:        pattern.refType,
+				ID:          id(pattern.regex, str, submatch, text),
+				Text:        text,
+				Source:      source,
+				urlTemplate: pattern.urlTemplate,
+			}})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].index < matches[j].index
+	})
+
+	var references []*Reference
+
+	for _, m := range matches {
+		references = append(references, m.reference)
+	}
+
+	return references
+}
+
+// Returns the group "id" of the pattern, the first group of the pattern or the complete reference.
+func id(regex *regexp.Regexp, str string, submatch []int, text string) string {
+
+	group := regex.SubexpIndex("id")
+
+	if group < 0 && regex.NumSubexp() > 1 {
+		// the first group of the pattern follows the group "text"
+		group = regex.SubexpIndex("text") + 1
+	}
+
+	if group < 0 || submatch[2*group] < 0 {
+		return text
+	}
+
+	return str[submatch[2*group]:submatch[2*group+1]]
+}
+
+// Sets the url of the reference to the url template of its pattern or to the issue or pull request url of l.
+func (r *Reference) AddLink(l *links.Links) {
+
+	if r.urlTemplate != "" {
+		r.URL = strings.ReplaceAll(r.urlTemplate, "{id}", r.ID)
+	} else if r.Type == PULL_REQUEST {
+		r.URL = l.PullRequestURL(r.ID)
+	} else {
+		r.URL = l.IssueURL(r.ID)
+	}
+}
@@ -0,0 +1,79 @@
+package references
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func parse(t *testing.T, cfg config.ReferencesConfig, message string) []*Reference {
+	parser, err := NewParser(cfg)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitMessage, err := conventional_commits.ParseCommitMessage(message)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parser.Parse(commitMessage)
+}
+
+func TestParse_should_find_references_in_description_body_and_footers(t *testing.T) {
+	refs := parse(t, config.ReferencesConfig{}, "fix: Fix something (#78)\n\nSee !12 and #78\n\nCloses #45\nRefs: #46")
+
+	assert.Equal(t, []*Reference{
+		{Type: ISSUE, ID: "78", Text: "#78", Source: DESCRIPTION},
+		{Type: PULL_REQUEST, ID: "12", Text: "!12", Source: BODY},
+		{Type: ISSUE, ID: "45", Text: "#45", Source: FOOTER},
+		{Type: ISSUE, ID: "46", Text: "#46", Source: FOOTER},
+	}, refs)
+}
+
+func TestParse_should_ignore_anchors_and_entities(t *testing.T) {
+	refs := parse(t, config.ReferencesConfig{}, "fix: See https://example.com/page#1, /#2 and &#123;")
+
+	assert.Empty(t, refs)
+}
+
+func TestParse_should_use_configured_patterns(t *testing.T) {
+	refs := parse(t, config.ReferencesConfig{Patterns: []config.ReferencePattern{
+		{Name: "jira", URL: "https://jira.example.com/browse/{id}"},
+		{Name: "pr", Regex: `PR (?P<id>\d+)`, Type: "pull_request"},
+	}}, "feat: Add feature for PROJ-123 in PR 7 (#78)\n\nRefs: OTHER-1")
+
+	assert.Equal(t, []*Reference{
+		{Type: ISSUE, ID: "PROJ-123", Text: "PROJ-123", Source: DESCRIPTION, urlTemplate: "https://jira.example.com/browse/{id}"},
+		{Type: PULL_REQUEST, ID: "7", Text: "PR 7", Source: DESCRIPTION},
+		{Type: ISSUE, ID: "OTHER-1", Text: "OTHER-1", Source: FOOTER, urlTemplate: "https://jira.example.com/browse/{id}"},
+	}, refs)
+}
+
+func TestNewParser_should_fail_on_invalid_patterns(t *testing.T) {
+	_, err := NewParser(config.ReferencesConfig{Patterns: []config.ReferencePattern{{Name: "custom"}}})
+	assert.EqualError(t, err, "Reference pattern \"custom\" is no built-in pattern and has no regex")
+
+	_, err = NewParser(config.ReferencesConfig{Patterns: []config.ReferencePattern{{Name: "github", Type: "ticket"}}})
+	assert.EqualError(t, err, "Unknown reference type \"ticket\" (expected issue or pull_request)")
+}
+
+func TestAddLink_should_prefer_url_template_of_pattern(t *testing.T) {
+	l := &links.Links{Issue: "https://example.com/issues/{id}", PullRequest: "https://example.com/pulls/{id}"}
+
+	jira := &Reference{Type: ISSUE, ID: "PROJ-1", urlTemplate: "https://jira.example.com/browse/{id}"}
+	issue := &Reference{Type: ISSUE, ID: "1"}
+	pullRequest := &Reference{Type: PULL_REQUEST, ID: "2"}
+
+	jira.AddLink(l)
+	issue.AddLink(l)
+	pullRequest.AddLink(l)
+
+	assert.Equal(t, "https://jira.example.com/browse/PROJ-1", jira.URL)
+	assert.Equal(t, "https://example.com/issues/1", issue.URL)
+	assert.Equal(t, "https://example.com/pulls/2", pullRequest.URL)
+}
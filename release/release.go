@@ -0,0 +1,230 @@
+package release
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/bump"
+	"github.com/psanetra/git-semver/changelog"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/semver"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const DEFAULT_CHANGELOG_FILE = "CHANGELOG.md"
+
+type ReleaseOptions struct {
+	// NextOptions.ReleaseCommitMessage is used as template for the release commit message
+	next.NextOptions
+	// Prefix is prepended to the version to build the tag name (e.g. "v")
+	Prefix string
+	// ChangelogFile is relative to the root of the worktree
+	ChangelogFile  string
+	ChangelogStyle changelog.Style
+	// TemplateFile is a text/template file for the changelog section. The built-in markdown template is used if it is empty.
+	TemplateFile string
+	// Date of the release in the changelog. Defaults to the current time.
+	Date time.Time
+	// ReleaseNotes configure the references and sections of the release notes
+	ReleaseNotes release_notes.Options
+	// Links are used to link commits, issues and the changes since the previous version. There are no links if it is nil.
+	Links *links.Links
+	// BumpFiles are updated with the new version and become part of the release commit
+	BumpFiles []config.BumpFile
+	// DryRun only plans the release without changing the changelog file or creating a commit and a tag
+	DryRun bool
+	// SecurityMaxUnreleasedCommits fails the release if a security fix is followed by more commits since the latest
+	// version (see next.AssertSecurityFixesReleased). 0 disables the check.
+	SecurityMaxUnreleasedCommits int
+}
+
+type ReleaseResult struct {
+	Version           *semver.Version
+	TagName           string
+	CommitMessage     string
+	ChangelogFile     string
+	PreviousChangelog string
+	Changelog         string
+	BumpResults       []*bump.FileResult
+	// Commit is the hash of the release commit. It is empty for dry runs.
+	Commit plumbing.Hash
+}
+
+// Calculates the next version, inserts a section for it into the changelog file, updates the version in BumpFiles,
+// commits these files and tags the release commit.
+func Release(options ReleaseOptions) (*ReleaseResult, error) {
+
+	if options.ReleaseCommitMessage == "" {
+		options.ReleaseCommitMessage = next.DEFAULT_RELEASE_COMMIT_MESSAGE
+	}
+
+	if options.ChangelogFile == "" {
+		options.ChangelogFile = DEFAULT_CHANGELOG_FILE
+	}
+
+	if options.ChangelogStyle == "" {
+		options.ChangelogStyle = changelog.KEEP_A_CHANGELOG
+	}
+
+	if options.Date.IsZero() {
+		options.Date = time.Now()
+	}
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	worktree, err := repo.Worktree()
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open worktree")
+	}
+
+	nextVersion, err := next.Next(options.NextOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = next.AssertSecurityFixesReleased(options.NextOptions, options.SecurityMaxUnreleasedCommits); err != nil {
+		return nil, err
+	}
+
+	tagName := options.Prefix + nextVersion.ToString()
+
+	if _, err = repo.Tag(tagName); err == nil {
+		return nil, errors.Errorf("Version %s is already released (tag %s exists)", nextVersion.ToString(), tagName)
+	}
+
+	// the previous version is determined like the next version, e.g. stable releases follow the previous stable release
+	previousTag, commits, err := next.PrecedingVersionLog(repo, options.NextOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := release_notes.LoadTemplate(options.TemplateFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notes := release_notes.New(commits, options.ReleaseNotes)
+	notes.Version = nextVersion.ToString()
+	notes.TagName = tagName
+	notes.Date = options.Date
+
+	if previousTag != nil {
+		notes.AddLinks(options.Links, previousTag.Name().Short())
+	} else {
+		notes.AddLinks(options.Links, "")
+	}
+
+	content, err := notes.Render(tmpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changelogPath := filepath.Join(worktree.Filesystem.Root(), options.ChangelogFile)
+
+	previousChangelog, err := os.ReadFile(changelogPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.WithMessage(err, "Could not read "+options.ChangelogFile)
+	}
+
+	result := &ReleaseResult{
+		Version:           nextVersion,
+		TagName:           tagName,
+		CommitMessage:     next.FormatReleaseCommitMessage(options.ReleaseCommitMessage, nextVersion.ToString()),
+		ChangelogFile:     options.ChangelogFile,
+		PreviousChangelog: string(previousChangelog),
+		Changelog: changelog.Update(string(previousChangelog), changelog.Section{
+			Version:    nextVersion,
+			Date:       options.Date,
+			CompareURL: notes.CompareURL,
+			Content:    content,
+		}, options.ChangelogStyle),
+	}
+
+	result.BumpResults, err = bump.Plan(worktree.Filesystem.Root(), options.BumpFiles, nextVersion.ToString())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	if err = assertNothingStaged(worktree); err != nil {
+		return nil, err
+	}
+
+	if err = os.WriteFile(changelogPath, []byte(result.Changelog), 0666); err != nil {
+		return nil, errors.WithMessage(err, "Could not write "+options.ChangelogFile)
+	}
+
+	if _, err = worktree.Add(filepath.ToSlash(options.ChangelogFile)); err != nil {
+		return nil, errors.WithMessage(err, "Could not add "+options.ChangelogFile)
+	}
+
+	if err = bump.Write(worktree.Filesystem.Root(), result.BumpResults); err != nil {
+		return nil, err
+	}
+
+	for _, bumpResult := range result.BumpResults {
+		if !bumpResult.Changed() {
+			continue
+		}
+
+		if _, err = worktree.Add(filepath.ToSlash(bumpResult.Path)); err != nil {
+			return nil, errors.WithMessage(err, "Could not add "+bumpResult.Path)
+		}
+	}
+
+	result.Commit, err = worktree.Commit(result.CommitMessage, &git.CommitOptions{})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not create release commit")
+	}
+
+	logger.Logger.Infoln("Created release commit", result.Commit.String())
+
+	if _, err = git_utils.CreateTagRef(repo, tagName, result.Commit); err != nil {
+		return nil, errors.WithMessage(err, "Release commit "+result.Commit.String()+" was created, but tag "+tagName+" could not be created")
+	}
+
+	logger.Logger.Infoln("Created tag", tagName)
+
+	return result, nil
+}
+
+func assertNothingStaged(worktree *git.Worktree) error {
+
+	status, err := worktree.Status()
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not get status of worktree")
+	}
+
+	for file, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked {
+			return errors.New("There are staged changes (e.g. " + file + "), which would become part of the release commit")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package release
+
+import (
+	"github.com/psanetra/git-semver/changelog"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var date = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func TestRelease_should_update_changelog_commit_and_tag(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.WriteFile(t, repo, "CHANGELOG.md", "## 1.0.0\n\n### Features\n\n* Add feature\n")
+	test_utils.Commit(t, repo, "fix: Add fix")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Date:        date,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", result.Version.ToString())
+
+	changelog, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "## [1.0.1] - 2026-10-18\n\n### Bug Fixes\n\n* Add fix\n\n## 1.0.0\n\n### Features\n\n* Add feature\n", string(changelog))
+
+	head, err := repo.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, result.Commit, head.Hash())
+
+	commit, err := repo.CommitObject(head.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, "chore(release): 1.0.1", commit.Message)
+
+	tag, err := repo.Tag("v1.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, head.Hash(), tag.Hash())
+}
+
+func TestRelease_should_not_change_anything_on_dry_run(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions:    next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:         "v",
+		DryRun:         true,
+		Date:           date,
+		ChangelogStyle: changelog.CONVENTIONAL_CHANGELOG,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", result.Version.ToString())
+	assert.Equal(t, "", result.PreviousChangelog)
+	assert.Equal(t, "# Changelog\n\n## 1.0.0 (2026-10-18)\n\n### Features\n\n* Add feature\n", result.Changelog)
+
+	_, err = os.Stat(filepath.Join(dir, "CHANGELOG.md"))
+	assert.True(t, os.IsNotExist(err))
+
+	head, err := repo.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, hash, head.Hash())
+
+	_, err = repo.Tag("v1.0.0")
+	assert.NotNil(t, err)
+}
+
+func TestRelease_should_use_custom_commit_message(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{
+			Workdir:              dir,
+			Stable:               true,
+			MajorVersionFilter:   -1,
+			ReleaseCommitMessage: "release: v{version}",
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "release: v1.0.0", result.CommitMessage)
+
+	_, err = repo.Tag("1.0.0")
+	assert.Nil(t, err)
+}
+
+func TestRelease_should_fail_if_version_is_already_released(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+
+	_, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+	})
+
+	assert.EqualError(t, err, "Version 1.0.0 is already released (tag v1.0.0 exists)")
+}
+
+func TestRelease_should_commit_bumped_files(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.WriteFile(t, repo, "package.json", `{"name": "example", "version": "0.0.0"}`)
+	test_utils.Commit(t, repo, "feat: Add feature")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Date:        date,
+		BumpFiles:   []config.BumpFile{{Path: "package.json"}},
+	})
+
+	assert.Nil(t, err)
+
+	packageJson, err := os.ReadFile(filepath.Join(dir, "package.json"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "example", "version": "1.0.0"}`, string(packageJson))
+
+	commit, err := repo.CommitObject(result.Commit)
+	assert.Nil(t, err)
+
+	file, err := commit.File("package.json")
+	assert.Nil(t, err)
+
+	content, err := file.Contents()
+	assert.Nil(t, err)
+	assert.Equal(t, string(packageJson), content)
+}
+
+func TestRelease_should_link_commits_and_versions(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	hash := test_utils.Commit(t, repo, "fix: Add fix")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Date:        date,
+		Links: &links.Links{
+			Commit:  "https://example.com/commit/{hash}",
+			Compare: "https://example.com/compare/{from}...{to}",
+		},
+		DryRun: true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "# Changelog\n\n"+
+		"All notable changes to this project will be documented in this file.\n\n"+
+		"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),\n"+
+		"and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).\n\n"+
+		"## [1.0.1] - 2026-10-18\n\n### Bug Fixes\n\n"+
+		"* Add fix (["+hash.String()[:7]+"](https://example.com/commit/"+hash.String()+"))\n\n"+
+		"[1.0.1]: https://example.com/compare/v1.0.0...v1.0.1\n", result.Changelog)
+}
+
+func TestRelease_should_compare_stable_release_with_previous_stable_release(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "feat: Add another feature")
+	test_utils.Tag(t, repo, "v1.1.0-rc.1")
+	test_utils.Commit(t, repo, "fix: Add fix")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Date:        date,
+		Links:       &links.Links{Compare: "https://example.com/compare/{from}...{to}"},
+		DryRun:      true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0", result.Version.ToString())
+	assert.Contains(t, result.Changelog, "[1.1.0]: https://example.com/compare/v1.0.0...v1.1.0\n")
+	assert.Contains(t, result.Changelog, "* Add another feature\n")
+	assert.Contains(t, result.Changelog, "* Add fix\n")
+}
+
+func TestRelease_should_list_commits_since_previous_pre_release(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "feat: Add another feature")
+	test_utils.Tag(t, repo, "v1.1.0-rc.1")
+	test_utils.Commit(t, repo, "fix: Add fix")
+
+	result, err := Release(ReleaseOptions{
+		NextOptions: next.NextOptions{
+			Workdir:            dir,
+			Stable:             true,
+			MajorVersionFilter: -1,
+			PreReleaseOptions:  semver.PreReleaseOptions{Label: "rc", AppendCounter: true},
+		},
+		Prefix: "v",
+		Date:   date,
+		Links:  &links.Links{Compare: "https://example.com/compare/{from}...{to}"},
+		DryRun: true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0-rc.2", result.Version.ToString())
+	assert.Contains(t, result.Changelog, "[1.1.0-rc.2]: https://example.com/compare/v1.1.0-rc.1...v1.1.0-rc.2\n")
+	assert.Contains(t, result.Changelog, "* Add fix\n")
+	assert.NotContains(t, result.Changelog, "Add another feature")
+}
+
+func TestRelease_should_fail_if_security_fixes_are_unreleased_for_too_many_commits(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	security := test_utils.Commit(t, repo, "fix(deps): Bump yaml\n\nRefs: GHSA-8r8j-xvfj-36f9")
+	test_utils.Commit(t, repo, "feat: Add endpoint")
+	test_utils.Commit(t, repo, "fix: Fix endpoint")
+
+	options := ReleaseOptions{
+		NextOptions:                  next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:                       "v",
+		Date:                         date,
+		DryRun:                       true,
+		SecurityMaxUnreleasedCommits: 1,
+	}
+
+	_, err := Release(options)
+
+	assert.EqualError(t, err, "Security fix "+security.String()[:7]+" is unreleased for 2 commits (maximum 1)")
+
+	options.SecurityMaxUnreleasedCommits = 2
+	result, err := Release(options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0", result.Version.ToString())
+}
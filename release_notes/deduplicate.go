@@ -0,0 +1,203 @@
+package release_notes
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/logger"
+	"sort"
+	"strings"
+)
+
+// Merges duplicate commits (see Deduplication) into the first of these commits, which is kept as entry. The other
+// commits become the duplicates of the entry. Commits with the same normalized change type, scope, description and
+// breaking changes have the same header. Duplicates are transitive, e.g. a commit with the header of one commit and the
+// changes of another commit merges all three commits.
+func deduplicate(commits []*Commit, deduplication Deduplication) []*Commit {
+
+	if deduplication == NO_DEDUPLICATION {
+		return commits
+	}
+
+	// groups[i] is the index of a commit of the same group (union-find)
+	groups := make([]int, len(commits))
+
+	var group func(i int) int
+	group = func(i int) int {
+		if groups[i] != i {
+			groups[i] = group(groups[i])
+		}
+
+		return groups[i]
+	}
+
+	firstByKey := make(map[string]int)
+
+	join := func(i int, key string) {
+		if first, ok := firstByKey[key]; ok {
+			groups[group(i)] = group(first)
+		} else {
+			firstByKey[key] = i
+		}
+	}
+
+	for i, commit := range commits {
+		groups[i] = i
+
+		if deduplication != SAME_CHANGES {
+			join(i, "header\x00"+commit.headerKey())
+		}
+
+		if deduplication != SAME_HEADER {
+			if id := commit.patchID(); id != "" {
+				join(i, "patch\x00"+id)
+			}
+		}
+	}
+
+	var entries []*Commit
+	entriesByGroup := make(map[int]*Commit)
+
+	for i, commit := range commits {
+		if entry, ok := entriesByGroup[group(i)]; ok {
+			entry.merge(commit)
+			continue
+		}
+
+		entriesByGroup[group(i)] = commit
+		entries = append(entries, commit)
+	}
+
+	return entries
+}
+
+func (c *Commit) headerKey() string {
+
+	header := []string{
+		strings.ToLower(string(c.ChangeType)),
+		strings.ToLower(strings.TrimSpace(c.Scope)),
+		normalizeDescription(c.Description),
+	}
+
+	if c.ContainsBreakingChange {
+		descriptions := c.BreakingChangeDescriptions()
+		sort.Strings(descriptions)
+		header = append(header, "!"+strings.Join(descriptions, "\n"))
+	}
+
+	return strings.Join(header, "\x00")
+}
+
+// Returns PatchID. It is computed from the git commit on first use.
+func (c *Commit) patchID() string {
+
+	if c.PatchID == "" && c.gitCommit != nil {
+		c.PatchID = patchID(c.gitCommit)
+		c.gitCommit = nil
+	}
+
+	return c.PatchID
+}
+
+// Returns the lower case description with single spaces and without trailing period.
+func normalizeDescription(description string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Join(strings.Fields(description), " ")), ".")
+}
+
+// Adds the commit and its duplicates to the duplicates of c and adds its references, which are not referenced by c.
+func (c *Commit) merge(commit *Commit) {
+
+	c.Duplicates = append(c.Duplicates, commit)
+	c.Duplicates = append(c.Duplicates, commit.Duplicates...)
+	commit.Duplicates = nil
+
+	texts := make(map[string]bool)
+
+	for _, reference := range c.References {
+		texts[reference.Text] = true
+	}
+
+	for _, reference := range commit.References {
+		if !texts[reference.Text] {
+			texts[reference.Text] = true
+			c.References = append(c.References, reference)
+		}
+	}
+}
+
+// Returns the commit followed by its duplicates.
+func (c *Commit) AllCommits() []*Commit {
+	return append([]*Commit{c}, c.Duplicates...)
+}
+
+// Returns a patch id similar to git-patch-id, which is the same for commits with the same changes (e.g. cherry-picks).
+// Line numbers and whitespace are ignored. Returns an empty string for merge commits, root commits and commits without
+// changes.
+func patchID(commit *object.Commit) string {
+
+	if commit.NumParents() != 1 {
+		return ""
+	}
+
+	parent, err := commit.Parent(0)
+
+	if err != nil {
+		logger.Logger.Debugln("Could not read parent of commit", commit.Hash, err)
+		return ""
+	}
+
+	patch, err := parent.Patch(commit)
+
+	if err != nil {
+		logger.Logger.Debugln("Could not diff commit", commit.Hash, err)
+		return ""
+	}
+
+	filePatches := patch.FilePatches()
+
+	if len(filePatches) == 0 {
+		return ""
+	}
+
+	hash := sha1.New()
+
+	for _, filePatch := range filePatches {
+		from, to := filePatch.Files()
+
+		for _, file := range []diff.File{from, to} {
+			if file == nil {
+				hash.Write([]byte("/dev/null\x00"))
+			} else {
+				hash.Write([]byte(file.Path() + "\x00"))
+			}
+		}
+
+		if filePatch.IsBinary() {
+			if to != nil {
+				hash.Write([]byte(to.Hash().String()))
+			}
+
+			continue
+		}
+
+		for _, chunk := range filePatch.Chunks() {
+			var op string
+
+			switch chunk.Type() {
+			case diff.Add:
+				op = "+"
+			case diff.Delete:
+				op = "-"
+			default:
+				continue
+			}
+
+			for _, line := range strings.Split(strings.TrimSuffix(chunk.Content(), "\n"), "\n") {
+				hash.Write([]byte(op + strings.Join(strings.Fields(line), "") + "\n"))
+			}
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
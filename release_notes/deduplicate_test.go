@@ -0,0 +1,166 @@
+package release_notes
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFromCommits_should_merge_commits_with_same_header_or_changes(t *testing.T) {
+	first := commit(conventional_commits.FIX, "API", "Fix  crash.", "alice")
+	first.PatchID = "1"
+	second := commit(conventional_commits.FIX, "api", "fix crash", "bob")
+	second.PatchID = "2"
+	cherryPick := commit(conventional_commits.FIX, "api", "Fix crash of the API", "carol")
+	cherryPick.PatchID = "1"
+	other := commit(conventional_commits.FEATURE, "api", "Fix crash", "bob")
+	other.PatchID = "3"
+
+	notes := FromCommits([]*Commit{first, second, cherryPick, other}, nil)
+
+	assert.Equal(t, []*Commit{first, other}, notes.Commits)
+	assert.Equal(t, []*Commit{second, cherryPick}, first.Duplicates)
+}
+
+func TestFromCommits_should_merge_commits_transitively(t *testing.T) {
+	first := commit(conventional_commits.FIX, "", "Fix crash", "alice")
+	first.PatchID = "1"
+	second := commit(conventional_commits.FIX, "", "Fix the crash", "bob")
+	second.PatchID = "2"
+	third := commit(conventional_commits.FIX, "", "Fix the crash", "carol")
+	third.PatchID = "1"
+
+	notes := FromCommits([]*Commit{first, second, third}, nil)
+
+	assert.Equal(t, []*Commit{first}, notes.Commits)
+	assert.Equal(t, []*Commit{second, third}, first.Duplicates)
+}
+
+func TestFromCommits_should_merge_commits_with_same_changes_if_configured(t *testing.T) {
+	first := commit(conventional_commits.FIX, "", "Fix crash", "alice")
+	first.PatchID = "1"
+	second := commit(conventional_commits.FIX, "", "Fix crash", "bob")
+	second.PatchID = "2"
+	cherryPick := commit(conventional_commits.FIX, "", "Fix crash again", "carol")
+	cherryPick.PatchID = "1"
+
+	layout, err := NewLayout(config.ChangelogConfig{Duplicates: "changes"})
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{first, second, cherryPick}, layout)
+
+	assert.Equal(t, []*Commit{first, second}, notes.Commits)
+	assert.Equal(t, []*Commit{cherryPick}, first.Duplicates)
+}
+
+func TestFromCommits_should_merge_commits_with_same_header_if_configured(t *testing.T) {
+	first := commit(conventional_commits.FIX, "API", "Fix  crash.", "alice")
+	first.ShortHash = "aaaaaaa"
+	first.References = []*references.Reference{{Type: references.ISSUE, Text: "#1"}}
+	second := commit(conventional_commits.FIX, "api", "fix crash", "bob")
+	second.ShortHash = "bbbbbbb"
+	second.References = []*references.Reference{{Type: references.ISSUE, Text: "#1"}, {Type: references.ISSUE, Text: "#2"}}
+	other := commit(conventional_commits.FEATURE, "api", "Fix crash", "bob")
+
+	layout, err := NewLayout(config.ChangelogConfig{Duplicates: "header"})
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{first, second, other}, layout)
+
+	assert.Equal(t, []*Commit{first, other}, notes.Commits)
+	assert.Equal(t, []*Commit{second}, first.Duplicates)
+	assert.Equal(t, []string{"#1", "#2"}, []string{first.References[0].Text, first.References[1].Text})
+	assert.Equal(t, []string{"alice", "bob"}, notes.Authors)
+	assert.Len(t, notes.Issues, 2)
+}
+
+func TestFromCommits_should_not_merge_different_breaking_changes(t *testing.T) {
+	first := commit(conventional_commits.FEATURE, "", "Change API", "")
+	first.ContainsBreakingChange = true
+	first.Footers = map[string][]string{"BREAKING CHANGE": {"Removed a"}}
+	second := commit(conventional_commits.FEATURE, "", "Change API", "")
+	second.ContainsBreakingChange = true
+	second.Footers = map[string][]string{"BREAKING CHANGE": {"Removed b"}}
+
+	layout, err := NewLayout(config.ChangelogConfig{Duplicates: "header"})
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{first, second}, layout)
+
+	assert.Len(t, notes.Commits, 2)
+	assert.Len(t, notes.BreakingChanges, 2)
+}
+
+func TestFromCommits_should_not_merge_commits_if_deduplication_is_disabled(t *testing.T) {
+	first := commit(conventional_commits.FIX, "", "Fix crash", "alice")
+	first.PatchID = "1"
+	second := commit(conventional_commits.FIX, "", "Fix crash", "bob")
+	second.PatchID = "1"
+
+	layout, err := NewLayout(config.ChangelogConfig{Duplicates: "none"})
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{first, second}, layout)
+
+	assert.Equal(t, []*Commit{first, second}, notes.Commits)
+
+	_, err = NewLayout(config.ChangelogConfig{Duplicates: "patch"})
+	assert.EqualError(t, err, "Unknown deduplication \"patch\" (expected any, changes, header or none)")
+}
+
+func TestNew_should_merge_commits_with_same_patch(t *testing.T) {
+	repo, _ := test_utils.InitRepo(t)
+
+	commitFile(t, repo, "a\n", "chore: Init")
+	commitFile(t, repo, "a\nb\n", "fix(app): Fix crash")
+	commitFile(t, repo, "a\n", "chore: Revert fix")
+	commitFile(t, repo, "a\n  b\n", "fix(app): Fix the crash again")
+	commitFile(t, repo, "a\n  b\nc\n", "fix(app): Fix another crash")
+
+	head, err := repo.Head()
+	assert.Nil(t, err)
+
+	log, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	assert.Nil(t, err)
+
+	var commits []*object.Commit
+	assert.Nil(t, log.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	}))
+
+	notes := New(commits, Options{})
+	notes.AddLinks(&links.Links{Commit: "https://example.com/{hash}"}, "")
+
+	assert.Len(t, notes.Sections, 1)
+	assert.Len(t, notes.Sections[0].Commits, 2)
+
+	other := notes.Sections[0].Commits[0]
+	assert.Equal(t, commits[0].Hash.String(), other.Hash)
+	assert.Empty(t, other.Duplicates)
+
+	entry := notes.Sections[0].Commits[1]
+	assert.Equal(t, commits[1].Hash.String(), entry.Hash)
+	assert.Len(t, entry.Duplicates, 1)
+	assert.Equal(t, commits[3].Hash.String(), entry.Duplicates[0].Hash)
+	assert.Equal(t,
+		"### Bug Fixes\n\n* **app** Fix another crash (["+other.ShortHash+"]("+other.URL+"))\n"+
+			"* **app** Fix the crash again (["+entry.ShortHash+"]("+entry.URL+"), ["+entry.Duplicates[0].ShortHash+"]("+entry.Duplicates[0].URL+"))\n",
+		render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func commitFile(t *testing.T, repo *git.Repository, content string, message string) {
+	test_utils.WriteFile(t, repo, "app.txt", content)
+
+	worktree, err := repo.Worktree()
+	assert.Nil(t, err)
+
+	_, err = worktree.Commit(message, &git.CommitOptions{Author: &test_utils.Signature})
+	assert.Nil(t, err)
+}
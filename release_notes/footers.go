@@ -0,0 +1,154 @@
+package release_notes
+
+import (
+	"github.com/psanetra/git-semver/conventional_commits"
+	"sort"
+	"strings"
+)
+
+// Footer tokens, which control the entries of the release notes. Tokens and the values CHANGELOG_SKIP and
+// RELEASE_NOTE_NONE are case-insensitive.
+const (
+	// CHANGELOG_FOOTER_TOKEN replaces the description of an entry or hides the entry with the value CHANGELOG_SKIP
+	CHANGELOG_FOOTER_TOKEN = "Changelog"
+	// CHANGELOG_SECTION_FOOTER_TOKEN moves an entry to the section with this title. Unknown sections are added after
+	// the sections of the layout.
+	CHANGELOG_SECTION_FOOTER_TOKEN = "Changelog-Section"
+	// RELEASE_NOTE_FOOTER_TOKEN adds a note to the release notes
+	RELEASE_NOTE_FOOTER_TOKEN = "Release-Note"
+)
+
+// CHANGELOG_SKIP hides an entry. Breaking changes are listed anyway.
+const CHANGELOG_SKIP = "skip"
+
+// RELEASE_NOTE_NONE is the value of a RELEASE_NOTE_FOOTER_TOKEN footer, which adds no note
+const RELEASE_NOTE_NONE = "none"
+
+// Note is a note of a commit (see RELEASE_NOTE_FOOTER_TOKEN)
+type Note struct {
+	Commit *Commit `json:"commit"`
+	Text   string  `json:"text"`
+}
+
+// Applies the footers, which control the entries, to the commit. The description is replaced on a copy of the
+// commit message, because commit messages may be shared.
+func (c *Commit) applyFooters() {
+
+	for _, value := range footerValues(c.ConventionalCommitMessage, CHANGELOG_FOOTER_TOKEN) {
+		if strings.EqualFold(value, CHANGELOG_SKIP) {
+			c.Skipped = true
+		} else if value != "" && value != c.Description {
+			message := *c.ConventionalCommitMessage
+			message.Description = value
+			c.ConventionalCommitMessage = &message
+		}
+	}
+
+	for _, value := range footerValues(c.ConventionalCommitMessage, CHANGELOG_SECTION_FOOTER_TOKEN) {
+		c.Section = value
+	}
+
+	c.Notes = nil
+
+	for _, value := range footerValues(c.ConventionalCommitMessage, RELEASE_NOTE_FOOTER_TOKEN) {
+		if value != "" && !strings.EqualFold(value, RELEASE_NOTE_NONE) {
+			c.Notes = append(c.Notes, value)
+		}
+	}
+}
+
+// Returns the trimmed values of the footers with the token in any case ordered by the spelling of the token.
+func footerValues(message *conventional_commits.ConventionalCommitMessage, token string) []string {
+
+	var keys []string
+
+	for key := range message.Footers {
+		if strings.EqualFold(key, token) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var ret []string
+
+	for _, key := range keys {
+		for _, value := range message.Footers[key] {
+			ret = append(ret, strings.TrimSpace(value))
+		}
+	}
+
+	return ret
+}
+
+// Returns the notes of the commits including skipped commits.
+func notesOf(commits []*Commit) []*Note {
+
+	ret := []*Note{}
+
+	for _, commit := range commits {
+		for _, text := range commit.Notes {
+			ret = append(ret, &Note{Commit: commit, Text: text})
+		}
+	}
+
+	return ret
+}
+
+// Returns the commits, which are not skipped.
+func withoutSkipped(commits []*Commit) []*Commit {
+
+	var ret []*Commit
+
+	for _, commit := range commits {
+		if !commit.Skipped {
+			ret = append(ret, commit)
+		}
+	}
+
+	return ret
+}
+
+// Returns the sections of the layout followed by sections for unknown CHANGELOG_SECTION_FOOTER_TOKEN values.
+func sectionDefinitions(commits []*Commit, layout *Layout) []*SectionDefinition {
+
+	definitions := append([]*SectionDefinition{}, layout.Sections...)
+
+	for _, commit := range commits {
+		if commit.Section != "" && findSection(definitions, commit.Section) == nil {
+			definitions = append(definitions, &SectionDefinition{Title: commit.Section})
+		}
+	}
+
+	return definitions
+}
+
+// Returns the section of the commit. The section of a CHANGELOG_SECTION_FOOTER_TOKEN footer takes precedence over
+// the security section of security fixes and the section of the change type.
+func sectionOf(commit *Commit, definitions []*SectionDefinition, layout *Layout) *SectionDefinition {
+
+	if commit.Section != "" {
+		if definition := findSection(definitions, commit.Section); definition != nil {
+			return definition
+		}
+	}
+
+	if commit.IsSecurityFix() {
+		if definition := layout.securitySection(); definition != nil {
+			return definition
+		}
+	}
+
+	return layout.section(commit.ChangeType)
+}
+
+func findSection(definitions []*SectionDefinition, title string) *SectionDefinition {
+
+	for _, definition := range definitions {
+		if strings.EqualFold(definition.Title, title) {
+			return definition
+		}
+	}
+
+	return nil
+}
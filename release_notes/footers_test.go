@@ -0,0 +1,143 @@
+package release_notes
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func withFooters(c *Commit, footers map[string][]string) *Commit {
+	c.Footers = footers
+	return c
+}
+
+func TestFromCommits_should_skip_entries(t *testing.T) {
+	skipped := withFooters(commit(conventional_commits.FIX, "", "Fix typo", "alice"), map[string][]string{"changelog": {"Skip"}})
+	breaking := withFooters(commit(conventional_commits.FEATURE, "api", "Replace endpoint", "bob"), map[string][]string{
+		"Changelog":       {"skip"},
+		"BREAKING CHANGE": {"Use /v2/items instead of /items."},
+	})
+	breaking.ContainsBreakingChange = true
+	fix := commit(conventional_commits.FIX, "", "Fix crash", "carol")
+
+	notes := FromCommits([]*Commit{skipped, breaking, fix}, nil)
+
+	assert.Equal(t, []*Commit{fix}, notes.Commits)
+	assert.Equal(t, []*BreakingChange{{Commit: breaking, Scope: "api", Description: "Use /v2/items instead of /items."}}, notes.BreakingChanges)
+	assert.Equal(t, "### BREAKING CHANGES\n\n* **api** Use /v2/items instead of /items.\n\n### Bug Fixes\n\n* Fix crash\n", render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestFromCommits_should_replace_descriptions(t *testing.T) {
+	feature := withFooters(commit(conventional_commits.FEATURE, "", "Add flag", "alice"), map[string][]string{"Changelog": {"Add the `--dry-run` flag"}})
+	breaking := withFooters(commit(conventional_commits.FEATURE, "", "Rename option", "bob"), map[string][]string{
+		"Changelog":       {"Rename the `output` option"},
+		"BREAKING CHANGE": {"Rename `output` to `out` in the configuration."},
+	})
+	breaking.ContainsBreakingChange = true
+	message := feature.ConventionalCommitMessage
+
+	notes := FromCommits([]*Commit{feature, breaking}, nil)
+
+	assert.Equal(t, "Add flag", message.Description, "the commit message should not be changed")
+	assert.Equal(t, `### BREAKING CHANGES
+
+* Rename `+"`output` to `out`"+` in the configuration.
+
+### Features
+
+* Add the `+"`--dry-run`"+` flag
+* Rename the `+"`output`"+` option
+`, render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestFromCommits_should_move_entries_to_sections(t *testing.T) {
+	layout, err := NewLayout(config.ChangelogConfig{
+		Sections: []config.ChangelogSection{{Title: "Features", Types: []string{"feat"}}, {Title: "Bug Fixes", Types: []string{"fix"}}},
+		Hidden:   []string{"chore"},
+	})
+	assert.Nil(t, err)
+
+	moved := withFooters(commit(conventional_commits.FIX, "", "Fix race condition", "alice"), map[string][]string{"Changelog-Section": {"features"}})
+	security := withFooters(commit(conventional_commits.FIX, "", "Escape input", "bob"), map[string][]string{"Changelog-Section": {"Security"}})
+	hidden := withFooters(commit("chore", "", "Update base image", "carol"), map[string][]string{"Changelog-Section": {"Security"}})
+	fix := commit(conventional_commits.FIX, "", "Fix crash", "dave")
+	deprecation := withFooters(commit(conventional_commits.FEATURE, "", "Deprecate flag", "erin"), map[string][]string{"Changelog-Section": {"Deprecations"}})
+
+	notes := FromCommits([]*Commit{moved, security, hidden, fix, deprecation}, layout)
+
+	assert.Equal(t, `### Security
+
+* Escape input
+* Update base image
+
+### Features
+
+* Fix race condition
+
+### Bug Fixes
+
+* Fix crash
+
+### Deprecations
+
+* Deprecate flag
+`, render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestFromCommits_should_collect_release_notes(t *testing.T) {
+	feature := withFooters(commit(conventional_commits.FEATURE, "api", "Add endpoint", "alice"), map[string][]string{"Release-Note": {"Clients can page through items."}})
+	skipped := withFooters(commit(conventional_commits.FIX, "", "Fix typo", "bob"), map[string][]string{
+		"Changelog":    {"skip"},
+		"Release-Note": {"The docs were proofread."},
+	})
+	none := withFooters(commit(conventional_commits.FIX, "", "Fix crash", "carol"), map[string][]string{"Release-Note": {"NONE"}})
+
+	notes := FromCommits([]*Commit{feature, skipped, none}, nil)
+
+	assert.Equal(t, []*Note{{Commit: feature, Text: "Clients can page through items."}, {Commit: skipped, Text: "The docs were proofread."}}, notes.Notes)
+	assert.Equal(t, `### Release Notes
+
+* **api** Clients can page through items.
+* The docs were proofread.
+
+### Features
+
+* **api** Add endpoint
+
+### Bug Fixes
+
+* Fix crash
+`, render(t, notes, MARKDOWN_TEMPLATE))
+
+	renderer, err := NewRenderer(TEXT)
+	assert.Nil(t, err)
+
+	text, err := renderer.Render(notes)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `Release Notes:
+  - api: Clients can page through items.
+  - The docs were proofread.
+
+Features:
+  - api: Add endpoint
+
+Bug Fixes:
+  - Fix crash
+`, text)
+}
+
+func TestFromCommits_should_order_release_notes_of_differently_cased_footers(t *testing.T) {
+	feature := withFooters(commit(conventional_commits.FEATURE, "", "Add endpoint", "alice"), map[string][]string{
+		"release-note": {"Third"},
+		"Release-Note": {"First", "Second"},
+		"RELEASE-NOTE": {"Zeroth"},
+	})
+
+	for i := 0; i < 10; i++ {
+		notes := FromCommits([]*Commit{feature}, nil)
+
+		assert.Equal(t, []*Note{{Commit: feature, Text: "Zeroth"}, {Commit: feature, Text: "First"}, {Commit: feature, Text: "Second"}, {Commit: feature, Text: "Third"}}, notes.Notes)
+	}
+}
@@ -0,0 +1,199 @@
+package release_notes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"strings"
+	"text/template"
+)
+
+type Format string
+
+const (
+	MARKDOWN Format = "markdown"
+	JSON     Format = "json"
+	HTML     Format = "html"
+	ASCIIDOC Format = "asciidoc"
+	RST      Format = "rst"
+	TEXT     Format = "text"
+)
+
+var Formats = []Format{MARKDOWN, JSON, HTML, ASCIIDOC, RST, TEXT}
+
+// Renderer renders release notes in a specific format
+type Renderer interface {
+	// Render renders the release notes of a single version without version header
+	Render(notes *ReleaseNotes) (string, error)
+	// RenderAll renders the release notes of multiple versions with a header for each version
+	RenderAll(notes []*ReleaseNotes) (string, error)
+}
+
+func ParseFormat(format string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == format {
+			return f, nil
+		}
+	}
+
+	var names []string
+
+	for _, f := range Formats {
+		names = append(names, string(f))
+	}
+
+	return "", errors.Errorf("Unknown format \"%s\" (expected one of %s)", format, strings.Join(names, ", "))
+}
+
+// Returns the built-in renderer of the format.
+func NewRenderer(format Format) (Renderer, error) {
+
+	if format == JSON {
+		return jsonRenderer{}, nil
+	}
+
+	templates, ok := formatTemplates[format]
+
+	if !ok {
+		return nil, errors.Errorf("Unknown format \"%s\"", format)
+	}
+
+	notesTemplate, err := ParseTemplate(string(format), templates.notes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	documentTemplate, err := ParseTemplate(string(format)+"-document", templates.document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateRenderer{
+		notes:    notesTemplate,
+		document: documentTemplate,
+		// the markdown template keeps the whitespace of the former markdown renderer
+		trim: format != MARKDOWN,
+	}, nil
+}
+
+// Returns a renderer for the template file or the built-in renderer of the format if templateFile is empty.
+func LoadRenderer(format Format, templateFile string) (Renderer, error) {
+
+	if templateFile == "" {
+		return NewRenderer(format)
+	}
+
+	tmpl, err := LoadTemplate(templateFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemplateRenderer(tmpl), nil
+}
+
+// Returns a renderer for a custom template. Versions are rendered with markdown headers by RenderAll unless the
+// template defines a template named "document", which receives a list of release notes with the additional field
+// .Content containing the rendered release notes.
+func NewTemplateRenderer(tmpl *template.Template) Renderer {
+
+	renderer := &templateRenderer{
+		notes:    tmpl,
+		document: tmpl.Lookup("document"),
+	}
+
+	if renderer.document == nil {
+		renderer.document = template.Must(ParseTemplate("markdown-document", formatTemplates[MARKDOWN].document))
+	}
+
+	return renderer
+}
+
+// Returns true if the renderer was created from a custom template, which defines a template named "document".
+func DefinesDocument(renderer Renderer) bool {
+	r, ok := renderer.(*templateRenderer)
+
+	return ok && r.notes.Lookup("document") != nil
+}
+
+type templateRenderer struct {
+	notes    *template.Template
+	document *template.Template
+	// trim removes leading and trailing empty lines
+	trim bool
+}
+
+// documentEntry is passed to the document templates
+type documentEntry struct {
+	*ReleaseNotes
+	Content string
+}
+
+func (r *templateRenderer) Render(notes *ReleaseNotes) (string, error) {
+
+	content, err := notes.Render(r.notes)
+
+	if err != nil {
+		return "", err
+	}
+
+	if r.trim {
+		content = strings.Trim(content, "\n")
+
+		if content != "" {
+			content += "\n"
+		}
+	}
+
+	return content, nil
+}
+
+func (r *templateRenderer) RenderAll(notes []*ReleaseNotes) (string, error) {
+
+	var entries []*documentEntry
+
+	for _, n := range notes {
+		content, err := r.Render(n)
+
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, &documentEntry{ReleaseNotes: n, Content: content})
+	}
+
+	var buffer bytes.Buffer
+
+	if err := r.document.Execute(&buffer, entries); err != nil {
+		return "", errors.WithMessage(err, "Could not render release notes")
+	}
+
+	return buffer.String(), nil
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(notes *ReleaseNotes) (string, error) {
+	return marshalJson(notes)
+}
+
+func (jsonRenderer) RenderAll(notes []*ReleaseNotes) (string, error) {
+	if notes == nil {
+		notes = []*ReleaseNotes{}
+	}
+
+	return marshalJson(notes)
+}
+
+func marshalJson(value interface{}) (string, error) {
+
+	result, err := json.MarshalIndent(value, "", "  ")
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Could not marshal json")
+	}
+
+	return string(result) + "\n", nil
+}
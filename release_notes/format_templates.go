@@ -0,0 +1,152 @@
+package release_notes
+
+type formatTemplate struct {
+	// notes renders the release notes of a single version
+	notes string
+	// document renders a list of release notes with version headers
+	document string
+}
+
+const markdownDocumentTemplate = `
+{{- range $i, $entry := . }}{{ if $i }}
+{{ end }}## {{ link "markdown" (or .Version "Unreleased") .CompareURL }}{{ with date "2006-01-02" .Date }} ({{ . }}){{ end }}
+{{ with .Content }}
+{{ . }}{{ end }}{{ end -}}
+`
+
+const htmlTemplate = `
+{{ if .BreakingChanges }}
+<h3>BREAKING CHANGES</h3>
+<ul>
+{{- range .BreakingChanges }}
+  <li>{{ if .Scope }}<strong>{{ escapeHTML .Scope }}</strong> {{ end }}{{ linkReferences "html" .Description .Commit.References }}{{ with commitLinks "html" .Commit }} ({{ . }}){{ end }}{{ with .Body }}<p>{{ escapeHTML . }}</p>{{ end }}</li>
+{{- end }}
+</ul>
+{{ end }}
+{{- if .Notes }}
+<h3>Release Notes</h3>
+<ul>
+{{- range .Notes }}
+  <li>{{ if .Commit.Scope }}<strong>{{ escapeHTML .Commit.Scope }}</strong> {{ end }}{{ linkReferences "html" .Text .Commit.References }}{{ with commitLinks "html" .Commit }} ({{ . }}){{ end }}</li>
+{{- end }}
+</ul>
+{{ end }}
+{{- range .Sections }}
+<h3>{{ escapeHTML .Title }}</h3>
+<ul>
+{{- range .Commits }}
+  <li>{{ if .Scope }}<strong>{{ escapeHTML .Scope }}</strong> {{ end }}{{ linkReferences "html" .Description .References }}{{ with commitLinks "html" . }} ({{ . }}){{ end }}{{ range $i, $r := .AdditionalReferences }}{{ if $i }},{{ else }}, refs{{ end }} {{ link "html" $r.Text $r.URL }}{{ end }}{{ range .Advisories }}, {{ link "html" .ID .URL }}{{ end }}{{ with .Body }}<p>{{ escapeHTML . }}</p>{{ end }}</li>
+{{- end }}
+</ul>
+{{ end }}
+`
+
+const htmlDocumentTemplate = `
+{{- range $i, $entry := . }}{{ if $i }}
+{{ end }}<h2>{{ link "html" (or .Version "Unreleased") .CompareURL }}{{ with date "2006-01-02" .Date }} <small>({{ . }})</small>{{ end }}</h2>
+{{ with .Content }}
+{{ . }}{{ end }}{{ end -}}
+`
+
+const asciidocTemplate = `
+{{ if .BreakingChanges }}
+=== BREAKING CHANGES
+
+{{ range .BreakingChanges -}}
+* {{ if .Scope }}*{{ .Scope }}* {{ end }}{{ replace (linkReferences "asciidoc" .Description .Commit.References) "\n\n" "\n+\n" }}{{ with commitLinks "asciidoc" .Commit }} ({{ . }}){{ end }}{{ with .Body }}
++
+{{ replace . "\n\n" "\n+\n" }}{{ end }}
+{{ end }}{{ end }}
+{{- if .Notes }}
+=== Release Notes
+
+{{ range .Notes -}}
+* {{ if .Commit.Scope }}*{{ .Commit.Scope }}* {{ end }}{{ replace (linkReferences "asciidoc" .Text .Commit.References) "\n\n" "\n+\n" }}{{ with commitLinks "asciidoc" .Commit }} ({{ . }}){{ end }}
+{{ end }}{{ end }}
+{{- range .Sections }}
+=== {{ .Title }}
+
+{{ range .Commits -}}
+* {{ if .Scope }}*{{ .Scope }}* {{ end }}{{ linkReferences "asciidoc" .Description .References }}{{ with commitLinks "asciidoc" . }} ({{ . }}){{ end }}{{ range $i, $r := .AdditionalReferences }}{{ if $i }},{{ else }}, refs{{ end }} {{ link "asciidoc" $r.Text $r.URL }}{{ end }}{{ range .Advisories }}, {{ link "asciidoc" .ID .URL }}{{ end }}{{ with .Body }}
++
+{{ replace . "\n\n" "\n+\n" }}{{ end }}
+{{ end }}{{ end }}
+`
+
+const asciidocDocumentTemplate = `
+{{- range $i, $entry := . }}{{ if $i }}
+{{ end }}== {{ link "asciidoc" (or .Version "Unreleased") .CompareURL }}{{ with date "2006-01-02" .Date }} ({{ . }}){{ end }}
+{{ with .Content }}
+{{ . }}{{ end }}{{ end -}}
+`
+
+const rstTemplate = `
+{{ if .BreakingChanges }}
+BREAKING CHANGES
+----------------
+
+{{ range .BreakingChanges -}}
+* {{ if .Scope }}**{{ escapeRST .Scope }}** {{ end }}{{ indent 2 (linkReferences "rst" .Description .Commit.References) }}{{ with commitLinks "rst" .Commit }} ({{ . }}){{ end }}{{ with .Body }}
+
+  {{ indent 2 (escapeRST .) }}{{ end }}
+{{ end }}{{ end }}
+{{- if .Notes }}
+Release Notes
+-------------
+
+{{ range .Notes -}}
+* {{ if .Commit.Scope }}**{{ escapeRST .Commit.Scope }}** {{ end }}{{ indent 2 (linkReferences "rst" .Text .Commit.References) }}{{ with commitLinks "rst" .Commit }} ({{ . }}){{ end }}
+{{ end }}{{ end }}
+{{- range .Sections }}
+{{ .Title }}
+{{ underline "-" .Title }}
+
+{{ range .Commits -}}
+* {{ if .Scope }}**{{ escapeRST .Scope }}** {{ end }}{{ linkReferences "rst" .Description .References }}{{ with commitLinks "rst" . }} ({{ . }}){{ end }}{{ range $i, $r := .AdditionalReferences }}{{ if $i }},{{ else }}, refs{{ end }} {{ link "rst" $r.Text $r.URL }}{{ end }}{{ range .Advisories }}, {{ link "rst" .ID .URL }}{{ end }}{{ with .Body }}
+
+  {{ indent 2 (escapeRST .) }}{{ end }}
+{{ end }}{{ end }}
+`
+
+const rstDocumentTemplate = `
+{{- range $i, $entry := . }}{{ if $i }}
+{{ end }}{{ $title := link "rst" (or .Version "Unreleased") .CompareURL }}{{ with date "2006-01-02" .Date }}{{ $title = printf "%s (%s)" $title . }}{{ end }}
+{{- $title }}
+{{ underline "=" $title }}
+{{ with .Content }}
+{{ . }}{{ end }}{{ end -}}
+`
+
+const textTemplate = `
+{{ if .BreakingChanges }}
+BREAKING CHANGES:
+{{ range .BreakingChanges }}  - {{ with .Scope }}{{ . }}: {{ end }}{{ indent 4 .Description }}{{ with .Body }}
+    {{ indent 4 . }}{{ end }}
+{{ end }}{{ end }}
+{{- if .Notes }}
+Release Notes:
+{{ range .Notes }}  - {{ with .Commit.Scope }}{{ . }}: {{ end }}{{ indent 4 .Text }}
+{{ end }}{{ end }}
+{{- range .Sections }}
+{{ .Title }}:
+{{ range .Commits }}  - {{ with .Scope }}{{ . }}: {{ end }}{{ .Description }}{{ range $i, $r := .AdditionalReferences }}{{ if $i }},{{ else }}, refs{{ end }} {{ link "text" $r.Text $r.URL }}{{ end }}{{ range .Advisories }}, {{ link "text" .ID .URL }}{{ end }}{{ with .Body }}
+    {{ indent 4 . }}{{ end }}
+{{ end }}{{ end }}
+`
+
+const textDocumentTemplate = `
+{{- range $i, $entry := . }}{{ if $i }}
+{{ end }}{{ $title := or .Version "Unreleased" }}{{ with date "2006-01-02" .Date }}{{ $title = printf "%s (%s)" $title . }}{{ end }}
+{{- $title }}
+{{ underline "=" $title }}
+{{ with .Content }}
+{{ . }}{{ end }}{{ end -}}
+`
+
+var formatTemplates = map[Format]formatTemplate{
+	MARKDOWN: {MARKDOWN_TEMPLATE, markdownDocumentTemplate},
+	HTML:     {htmlTemplate, htmlDocumentTemplate},
+	ASCIIDOC: {asciidocTemplate, asciidocDocumentTemplate},
+	RST:      {rstTemplate, rstDocumentTemplate},
+	TEXT:     {textTemplate, textDocumentTemplate},
+}
@@ -0,0 +1,197 @@
+package release_notes
+
+import (
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func formatTestNotes() []*ReleaseNotes {
+	fix := commit(conventional_commits.FIX, "api", "Fix <b>", "alice")
+	fix.Body = "Line 1\n\nLine 2"
+
+	unreleased := FromCommits([]*Commit{fix}, nil)
+
+	released := FromCommits([]*Commit{commit(conventional_commits.FEATURE, "", "Add *feature*", "bob")}, nil)
+	released.Version = "1.0.0"
+	released.TagName = "v1.0.0"
+	released.Date = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	return []*ReleaseNotes{unreleased, released}
+}
+
+func renderAll(t *testing.T, format Format) string {
+	renderer, err := NewRenderer(format)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := renderer.RenderAll(formatTestNotes())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestParseFormat_should_fail_on_unknown_format(t *testing.T) {
+	_, err := ParseFormat("pdf")
+
+	assert.EqualError(t, err, "Unknown format \"pdf\" (expected one of markdown, json, html, asciidoc, rst, text)")
+}
+
+func TestRenderer_should_render_markdown(t *testing.T) {
+	assert.Equal(t, `## Unreleased
+
+### Bug Fixes
+
+* **api** Fix <b>
+Line 1
+
+Line 2
+
+## 1.0.0 (2026-10-18)
+
+### Features
+
+* Add *feature*
+`, renderAll(t, MARKDOWN))
+}
+
+func TestRenderer_should_render_html(t *testing.T) {
+	assert.Equal(t, `<h2>Unreleased</h2>
+
+<h3>Bug Fixes</h3>
+<ul>
+  <li><strong>api</strong> Fix &lt;b&gt;<p>Line 1
+
+Line 2</p></li>
+</ul>
+
+<h2>1.0.0 <small>(2026-10-18)</small></h2>
+
+<h3>Features</h3>
+<ul>
+  <li>Add *feature*</li>
+</ul>
+`, renderAll(t, HTML))
+}
+
+func TestRenderer_should_render_asciidoc(t *testing.T) {
+	assert.Equal(t, `== Unreleased
+
+=== Bug Fixes
+
+* *api* Fix <b>
++
+Line 1
++
+Line 2
+
+== 1.0.0 (2026-10-18)
+
+=== Features
+
+* Add *feature*
+`, renderAll(t, ASCIIDOC))
+}
+
+func TestRenderer_should_render_rst(t *testing.T) {
+	assert.Equal(t, `Unreleased
+==========
+
+Bug Fixes
+---------
+
+* **api** Fix <b>
+
+  Line 1
+
+  Line 2
+
+1.0.0 (2026-10-18)
+==================
+
+Features
+--------
+
+* Add \*feature\*
+`, renderAll(t, RST))
+}
+
+func TestRenderer_should_render_text(t *testing.T) {
+	assert.Equal(t, `Unreleased
+==========
+
+Bug Fixes:
+  - api: Fix <b>
+    Line 1
+
+    Line 2
+
+1.0.0 (2026-10-18)
+==================
+
+Features:
+  - Add *feature*
+`, renderAll(t, TEXT))
+}
+
+func TestRenderer_should_render_json(t *testing.T) {
+	renderer, err := NewRenderer(JSON)
+	assert.Nil(t, err)
+
+	result, err := renderer.Render(formatTestNotes()[1])
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+  "version": "1.0.0",
+  "tag_name": "v1.0.0",
+  "date": "2026-10-18T12:00:00Z",
+  "breaking_changes": [],
+  "notes": [],
+  "advisories": [],
+  "sections": [
+    {
+      "type": "feat",
+      "types": ["feat"],
+      "title": "Features",
+      "commits": [
+        {"type": "feat", "description": "Add *feature*", "hash": "", "short_hash": "", "author": "bob", "author_email": "", "date": "0001-01-01T00:00:00Z"}
+      ]
+    }
+  ],
+  "commits": [
+    {"type": "feat", "description": "Add *feature*", "hash": "", "short_hash": "", "author": "bob", "author_email": "", "date": "0001-01-01T00:00:00Z"}
+  ],
+  "authors": ["bob"],
+  "issues": [],
+  "contributors": []
+}`, result)
+}
+
+func TestTemplateRenderer_should_use_document_template(t *testing.T) {
+	tmpl, err := ParseTemplate("test", `{{ len .Commits }}{{ define "document" }}{{ range . }}[{{ or .Version "next" }}: {{ .Content }}]{{ end }}{{ end }}`)
+	assert.Nil(t, err)
+
+	result, err := NewTemplateRenderer(tmpl).RenderAll(formatTestNotes())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "[next: 1][1.0.0: 1]", result)
+}
+
+func TestDefinesDocument_should_only_return_true_for_templates_with_document(t *testing.T) {
+	withDocument, err := ParseTemplate("test", `{{ define "document" }}{{ end }}`)
+	assert.Nil(t, err)
+	withoutDocument, err := ParseTemplate("test", `{{ .Version }}`)
+	assert.Nil(t, err)
+	builtIn, err := NewRenderer(MARKDOWN)
+	assert.Nil(t, err)
+
+	assert.True(t, DefinesDocument(NewTemplateRenderer(withDocument)))
+	assert.False(t, DefinesDocument(NewTemplateRenderer(withoutDocument)))
+	assert.False(t, DefinesDocument(builtIn))
+}
@@ -0,0 +1,268 @@
+package release_notes
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+)
+
+// Layout defines the sections of the release notes
+type Layout struct {
+	// Sections are rendered in this order
+	Sections []*SectionDefinition
+	// Hidden change types are not contained in any section. Breaking changes are listed anyway.
+	Hidden []conventional_commits.ChangeType
+	// ScopeGrouping defines how the groups of each section are rendered by the markdown format
+	ScopeGrouping ScopeGrouping
+	// ScopeOrder contains the titles of the scope groups, which are ordered first
+	ScopeOrder []string
+	// ScopeAliases map scopes to the titles of their groups
+	ScopeAliases map[string]string
+	// NoScope is the title of the group of commits without scope
+	NoScope string
+	// Contributors renders a section with the contributors in the markdown format
+	Contributors bool
+	// Deduplication defines which commits are merged into a single entry
+	Deduplication Deduplication
+}
+
+type ScopeGrouping string
+
+const (
+	// NO_GROUPING renders all entries of a section in a single list
+	NO_GROUPING ScopeGrouping = ""
+	// HEADINGS renders a heading for each scope
+	HEADINGS ScopeGrouping = "headings"
+	// BOLD renders a bold title for each scope
+	BOLD ScopeGrouping = "bold"
+)
+
+type Deduplication string
+
+const (
+	// SAME_HEADER_OR_CHANGES merges commits with the same header or the same changes
+	SAME_HEADER_OR_CHANGES Deduplication = "any"
+	// SAME_CHANGES merges commits with the same changes (e.g. cherry-picks, even with reworded headers)
+	SAME_CHANGES Deduplication = "changes"
+	// SAME_HEADER merges commits with the same header (e.g. multiple commits of a pull request)
+	SAME_HEADER Deduplication = "header"
+	// NO_DEDUPLICATION lists all commits
+	NO_DEDUPLICATION Deduplication = "none"
+)
+
+// Returns SAME_HEADER_OR_CHANGES if deduplication is empty.
+func ParseDeduplication(deduplication string) (Deduplication, error) {
+	switch Deduplication(deduplication) {
+	case "":
+		return SAME_HEADER_OR_CHANGES, nil
+	case SAME_HEADER_OR_CHANGES, SAME_CHANGES, SAME_HEADER, NO_DEDUPLICATION:
+		return Deduplication(deduplication), nil
+	}
+
+	return "", errors.Errorf("Unknown deduplication \"%s\" (expected %s, %s, %s or %s)", deduplication, SAME_HEADER_OR_CHANGES, SAME_CHANGES, SAME_HEADER, NO_DEDUPLICATION)
+}
+
+// DEFAULT_NO_SCOPE is the default title of the group of commits without scope
+const DEFAULT_NO_SCOPE = "Other"
+
+func ParseScopeGrouping(grouping string) (ScopeGrouping, error) {
+	switch ScopeGrouping(grouping) {
+	case NO_GROUPING, HEADINGS, BOLD:
+		return ScopeGrouping(grouping), nil
+	}
+
+	return "", errors.Errorf("Unknown scope grouping \"%s\" (expected %s or %s)", grouping, HEADINGS, BOLD)
+}
+
+// SectionDefinition defines which commits are contained in a section
+type SectionDefinition struct {
+	Title string
+	Types []conventional_commits.ChangeType
+	// Other sections contain non-conventional commits and all commits, whose types are neither hidden nor contained in
+	// another section
+	Other bool
+	// Security sections contain all security fixes (see conventional_commits.ConventionalCommitMessage.IsSecurityFix)
+	// regardless of their change type, even if it is hidden
+	Security bool
+}
+
+// DEFAULT_SECURITY_SECTION is the title of the security section, which is added before the configured sections if
+// none of them is a security section
+const DEFAULT_SECURITY_SECTION = "Security"
+
+func defaultSecuritySection() *SectionDefinition {
+	return &SectionDefinition{Title: DEFAULT_SECURITY_SECTION, Types: []conventional_commits.ChangeType{conventional_commits.SECURITY}, Security: true}
+}
+
+// DefaultLayout contains security fixes, features and bug fixes
+var DefaultLayout = &Layout{
+	Sections: []*SectionDefinition{
+		defaultSecuritySection(),
+		{Title: "Features", Types: []conventional_commits.ChangeType{conventional_commits.FEATURE}},
+		{Title: "Bug Fixes", Types: []conventional_commits.ChangeType{conventional_commits.FIX}},
+	},
+	NoScope:       DEFAULT_NO_SCOPE,
+	Deduplication: SAME_HEADER_OR_CHANGES,
+}
+
+// Creates the layout of the configuration. The sections of DefaultLayout are used if there are no sections configured.
+// A section for security fixes is added first unless a configured section is a security section or contains the type
+// "security".
+func NewLayout(cfg config.ChangelogConfig) (*Layout, error) {
+
+	scopeGrouping, err := ParseScopeGrouping(cfg.Scopes.Grouping)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deduplication, err := ParseDeduplication(cfg.Duplicates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &Layout{
+		ScopeGrouping: scopeGrouping,
+		ScopeOrder:    cfg.Scopes.Order,
+		ScopeAliases:  cfg.Scopes.Aliases,
+		NoScope:       cfg.Scopes.NoScope,
+		Contributors:  cfg.Contributors.Section,
+		Deduplication: deduplication,
+	}
+
+	if layout.NoScope == "" {
+		layout.NoScope = DEFAULT_NO_SCOPE
+	}
+
+	if len(cfg.Sections) == 0 {
+		layout.Sections = DefaultLayout.Sections
+		return layout, nil
+	}
+
+	sectionsByType := make(map[conventional_commits.ChangeType]string)
+	hasOther := false
+	hasSecurity := false
+
+	for _, sectionConfig := range cfg.Sections {
+		if sectionConfig.Title == "" {
+			return nil, errors.New("Changelog sections must have a title")
+		}
+
+		if len(sectionConfig.Types) == 0 && !sectionConfig.Other && !sectionConfig.Security {
+			return nil, errors.Errorf("Changelog section \"%s\" has no types", sectionConfig.Title)
+		}
+
+		if sectionConfig.Other {
+			if hasOther {
+				return nil, errors.Errorf("Changelog section \"%s\" is not the only section for other changes", sectionConfig.Title)
+			}
+
+			hasOther = true
+		}
+
+		section := &SectionDefinition{Title: sectionConfig.Title, Other: sectionConfig.Other, Security: sectionConfig.Security}
+
+		for _, t := range sectionConfig.Types {
+			changeType := conventional_commits.ChangeType(t)
+
+			if title, exists := sectionsByType[changeType]; exists {
+				return nil, errors.Errorf("Change type %s is contained in the changelog sections \"%s\" and \"%s\"", t, title, sectionConfig.Title)
+			}
+
+			sectionsByType[changeType] = sectionConfig.Title
+			section.Types = append(section.Types, changeType)
+			section.Security = section.Security || changeType == conventional_commits.SECURITY
+		}
+
+		if section.Security {
+			if hasSecurity {
+				return nil, errors.Errorf("Changelog section \"%s\" is not the only section for security fixes", sectionConfig.Title)
+			}
+
+			hasSecurity = true
+		}
+
+		layout.Sections = append(layout.Sections, section)
+	}
+
+	if !hasSecurity {
+		layout.Sections = append([]*SectionDefinition{defaultSecuritySection()}, layout.Sections...)
+	}
+
+	for _, t := range cfg.Hidden {
+		layout.Hidden = append(layout.Hidden, conventional_commits.ChangeType(t))
+	}
+
+	return layout, nil
+}
+
+// Returns the change types of all sections in the order of the sections.
+func (l *Layout) Types() []conventional_commits.ChangeType {
+
+	var types []conventional_commits.ChangeType
+
+	for _, section := range l.Sections {
+		types = append(types, section.Types...)
+	}
+
+	return types
+}
+
+// Returns the section of the change type or nil if the change type is hidden or not contained in any section.
+// Non-conventional commits have an empty change type.
+func (l *Layout) section(changeType conventional_commits.ChangeType) *SectionDefinition {
+
+	for _, hidden := range l.Hidden {
+		if hidden == changeType {
+			return nil
+		}
+	}
+
+	var other *SectionDefinition
+
+	for _, section := range l.Sections {
+		for _, t := range section.Types {
+			if t == changeType {
+				return section
+			}
+		}
+
+		if section.Other {
+			other = section
+		}
+	}
+
+	return other
+}
+
+// Returns the security section or nil if there is none.
+func (l *Layout) securitySection() *SectionDefinition {
+
+	for _, section := range l.Sections {
+		if section.Security {
+			return section
+		}
+	}
+
+	return nil
+}
+
+// Returns the title of the scope group of a commit.
+func (l *Layout) scopeGroup(scope string) string {
+
+	if scope == "" {
+		return l.NoScope
+	}
+
+	if alias, ok := l.ScopeAliases[scope]; ok {
+		return alias
+	}
+
+	return scope
+}
+
+// Returns true if non-conventional commits are contained in the release notes.
+func (l *Layout) includesNonConventionalCommits() bool {
+	return l.section("") != nil
+}
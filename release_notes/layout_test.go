@@ -0,0 +1,168 @@
+package release_notes
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testChangelogConfig = config.ChangelogConfig{
+	Sections: []config.ChangelogSection{
+		{Title: "Performance", Types: []string{"perf"}},
+		{Title: "Features", Types: []string{"feat"}},
+		{Title: "Fixes and Reverts", Types: []string{"fix", "revert"}},
+		{Title: "Other changes", Other: true},
+	},
+	Hidden: []string{"chore"},
+}
+
+func TestFromCommits_should_use_configured_sections(t *testing.T) {
+	layout, err := NewLayout(testChangelogConfig)
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.FIX, "", "Fix", ""),
+		commit("revert", "", "Revert", ""),
+		commit(conventional_commits.FEATURE, "", "Feature", ""),
+		commit(conventional_commits.CHORE, "", "Chore", ""),
+		commit(conventional_commits.DOCS, "", "Docs", ""),
+		commit("", "", "Non-conventional commit", ""),
+	}, layout)
+
+	var sections []string
+	var descriptions [][]string
+
+	for _, section := range notes.Sections {
+		sections = append(sections, section.Title)
+		var sectionDescriptions []string
+
+		for _, commit := range section.Commits {
+			sectionDescriptions = append(sectionDescriptions, commit.Description)
+		}
+
+		descriptions = append(descriptions, sectionDescriptions)
+	}
+
+	assert.Equal(t, []string{"Features", "Fixes and Reverts", "Other changes"}, sections)
+	assert.Equal(t, [][]string{{"Feature"}, {"Fix", "Revert"}, {"Docs", "Non-conventional commit"}}, descriptions)
+	assert.Equal(t, conventional_commits.FIX, notes.Sections[1].Type)
+	assert.Equal(t, conventional_commits.ChangeType(""), notes.Sections[2].Type)
+}
+
+func TestFromCommits_should_order_breaking_changes_by_sections(t *testing.T) {
+	layout, err := NewLayout(testChangelogConfig)
+	assert.Nil(t, err)
+
+	feature := commit(conventional_commits.FEATURE, "", "Change API", "")
+	feature.ContainsBreakingChange = true
+	perf := commit(conventional_commits.PERF, "", "Change cache", "")
+	perf.ContainsBreakingChange = true
+
+	notes := FromCommits([]*Commit{feature, perf}, layout)
+
+	assert.Equal(t, []string{"Change cache", "Change API"}, []string{notes.BreakingChanges[0].Description, notes.BreakingChanges[1].Description})
+
+	notes = FromCommits([]*Commit{perf, feature}, nil)
+
+	assert.Equal(t, []string{"Change API", "Change cache"}, []string{notes.BreakingChanges[0].Description, notes.BreakingChanges[1].Description})
+}
+
+func TestNew_should_include_non_conventional_commits_for_other_changes(t *testing.T) {
+	repo, _ := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	hash := test_utils.Commit(t, repo, "Update dependencies\n\nSome details")
+
+	commit, err := repo.CommitObject(hash)
+	assert.Nil(t, err)
+	parent, err := commit.Parent(0)
+	assert.Nil(t, err)
+
+	layout, err := NewLayout(testChangelogConfig)
+	assert.Nil(t, err)
+
+	notes := New([]*object.Commit{commit, parent}, Options{Layout: layout})
+
+	assert.Len(t, notes.Sections, 2)
+	assert.Equal(t, "Update dependencies", notes.Sections[1].Commits[0].Description)
+	assert.Equal(t, "Some details", notes.Sections[1].Commits[0].Body)
+
+	assert.Len(t, New([]*object.Commit{commit, parent}, Options{}).Commits, 1)
+}
+
+func TestNewLayout_should_validate_sections(t *testing.T) {
+	_, err := NewLayout(config.ChangelogConfig{Sections: []config.ChangelogSection{{Types: []string{"feat"}}}})
+	assert.EqualError(t, err, "Changelog sections must have a title")
+
+	_, err = NewLayout(config.ChangelogConfig{Sections: []config.ChangelogSection{{Title: "Empty"}}})
+	assert.EqualError(t, err, "Changelog section \"Empty\" has no types")
+
+	_, err = NewLayout(config.ChangelogConfig{Sections: []config.ChangelogSection{
+		{Title: "Features", Types: []string{"feat"}},
+		{Title: "New", Types: []string{"feat"}},
+	}})
+	assert.EqualError(t, err, "Change type feat is contained in the changelog sections \"Features\" and \"New\"")
+}
+
+func TestLayout_should_provide_types_in_section_order(t *testing.T) {
+	layout, err := NewLayout(testChangelogConfig)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []conventional_commits.ChangeType{"security", "perf", "feat", "fix", "revert"}, layout.Types())
+
+	layout, err = NewLayout(config.ChangelogConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultLayout, layout)
+}
+
+func TestFromCommits_should_group_commits_by_scope(t *testing.T) {
+	layout, err := NewLayout(config.ChangelogConfig{Scopes: config.ChangelogScopes{
+		Grouping: "headings",
+		Order:    []string{"Frontend"},
+		Aliases:  map[string]string{"ui": "Frontend", "frontend": "Frontend"},
+	}})
+	assert.Nil(t, err)
+
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.FEATURE, "", "Misc", ""),
+		commit(conventional_commits.FEATURE, "db", "Index", ""),
+		commit(conventional_commits.FEATURE, "ui", "Button", ""),
+		commit(conventional_commits.FEATURE, "api", "Endpoint", ""),
+		commit(conventional_commits.FEATURE, "frontend", "Dialog", ""),
+	}, layout)
+
+	var titles []string
+
+	for _, group := range notes.Sections[0].ScopeGroups {
+		titles = append(titles, group.Title)
+	}
+
+	assert.Equal(t, []string{"Frontend", "api", "db", "Other"}, titles)
+	assert.Len(t, notes.Sections[0].ScopeGroups[0].Commits, 2)
+}
+
+func TestMarkdownTemplate_should_render_scope_groups(t *testing.T) {
+	commits := []*Commit{
+		commit(conventional_commits.FEATURE, "", "Misc", ""),
+		commit(conventional_commits.FEATURE, "api", "Endpoint", ""),
+	}
+
+	headings, err := NewLayout(config.ChangelogConfig{Scopes: config.ChangelogScopes{Grouping: "headings", NoScope: "General"}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "### Features\n\n#### api\n\n* Endpoint\n\n#### General\n\n* Misc\n", render(t, FromCommits(commits, headings), MARKDOWN_TEMPLATE))
+
+	bold, err := NewLayout(config.ChangelogConfig{Scopes: config.ChangelogScopes{Grouping: "bold"}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "### Features\n\n**api**\n\n* Endpoint\n\n**Other**\n\n* Misc\n", render(t, FromCommits(commits, bold), MARKDOWN_TEMPLATE))
+}
+
+func TestNewLayout_should_fail_on_unknown_scope_grouping(t *testing.T) {
+	_, err := NewLayout(config.ChangelogConfig{Scopes: config.ChangelogScopes{Grouping: "tree"}})
+
+	assert.EqualError(t, err, "Unknown scope grouping \"tree\" (expected headings or bold)")
+}
@@ -0,0 +1,420 @@
+package release_notes
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/contributors"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/overrides"
+	"github.com/psanetra/git-semver/references"
+	"github.com/psanetra/git-semver/version_log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReleaseNotes is the model of the release notes of a single version, which is passed to the templates
+type ReleaseNotes struct {
+	// Version is empty for unreleased changes
+	Version string `json:"version,omitempty"`
+	// TagName is empty for unreleased changes
+	TagName string `json:"tag_name,omitempty"`
+	// Date is zero for unreleased changes
+	Date time.Time `json:"date,omitzero"`
+	// CompareURL links the changes between the previous and this version. It is empty if there are no links.
+	CompareURL      string            `json:"compare_url,omitempty"`
+	BreakingChanges []*BreakingChange `json:"breaking_changes"`
+	// Notes contain the Release-Note footers of the commits
+	Notes []*Note `json:"notes"`
+	// Advisories contain the distinct CVE and GHSA IDs, which are referenced in the footers of the commits
+	Advisories []*Advisory `json:"advisories"`
+	// Sections contain the changes, which are no breaking changes without separate description, grouped by change type
+	Sections []*Section `json:"sections"`
+	// Commits contains all conventional commits of the version and the non-conventional commits if the layout has a
+	// section for other changes. Duplicates are merged into the most recent commit. Most recent commits are first.
+	Commits []*Commit `json:"commits"`
+	// Authors contains the distinct names of the authors of Commits
+	Authors []string `json:"authors"`
+	// Issues contains the distinct issues, which are referenced by Commits
+	Issues []*references.Reference `json:"issues"`
+	// Contributors contains the authors and co-authors of all commits of the version, ordered by their number of commits
+	Contributors []*contributors.Contributor `json:"contributors"`
+	// ContributorsSection defines if the markdown format renders a section with the contributors
+	ContributorsSection bool `json:"-"`
+	// ScopeGrouping defines how the scope groups of the sections are rendered by the markdown format
+	ScopeGrouping ScopeGrouping `json:"-"`
+}
+
+// Commit is a conventional commit with the metadata of the git commit
+type Commit struct {
+	*conventional_commits.ConventionalCommitMessage
+	Hash        string    `json:"hash"`
+	ShortHash   string    `json:"short_hash"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email"`
+	Date        time.Time `json:"date"`
+	// URL is empty if there are no links
+	URL string `json:"url,omitempty"`
+	// References are the issues and pull requests referenced in the commit message (e.g. "#123")
+	References []*references.Reference `json:"references,omitempty"`
+	// Duplicates are the commits with the same changes (e.g. cherry-picks) or the same header, which are merged into
+	// this commit. Their references are added to References.
+	Duplicates []*Commit `json:"duplicates,omitempty"`
+	// PatchID identifies the changes of the commit. It is empty for merge commits, root commits and commits without
+	// changes. It is only computed for commits, which might be duplicates.
+	PatchID string `json:"-"`
+	// gitCommit is used to compute the PatchID
+	gitCommit *object.Commit
+	// Section is the title of the section of a Changelog-Section footer. It overrides the section of the change type.
+	Section string `json:"section,omitempty"`
+	// Notes are the values of the Release-Note footers
+	Notes []string `json:"notes,omitempty"`
+	// Skipped entries have a "Changelog: skip" footer. They are only listed as breaking changes and notes.
+	Skipped bool `json:"-"`
+	// Advisories are the CVE and GHSA advisories referenced in the footers
+	Advisories []*Advisory `json:"advisories,omitempty"`
+}
+
+type BreakingChange struct {
+	Commit *Commit `json:"commit"`
+	Scope  string  `json:"scope,omitempty"`
+	// Description is the value of a BREAKING CHANGE footer or the description of the commit if there is no such footer
+	Description string `json:"description"`
+	// Body is the body of the commit if there is no BREAKING CHANGE footer
+	Body string `json:"body,omitempty"`
+}
+
+type Section struct {
+	// Type is the first change type of the section. It is empty for the section of other changes.
+	Type    conventional_commits.ChangeType   `json:"type"`
+	Types   []conventional_commits.ChangeType `json:"types"`
+	Title   string                            `json:"title"`
+	Commits []*Commit                         `json:"commits"`
+	// ScopeGroups contain the commits grouped by scope in the order of the layout. They are omitted in JSON, because
+	// they duplicate Commits.
+	ScopeGroups []*ScopeGroup `json:"-"`
+}
+
+// ScopeGroup contains the commits with the same scope or with scopes with the same alias
+type ScopeGroup struct {
+	// Title is the scope, its alias or the title for commits without scope
+	Title   string    `json:"title"`
+	Commits []*Commit `json:"commits"`
+}
+
+// Options configure the creation of release notes
+type Options struct {
+	// References extracts the references to issues and pull requests. The default parser is used if it is nil.
+	References *references.Parser
+	// Layout defines the sections. DefaultLayout is used if it is nil.
+	Layout *Layout
+	// Contributors collects the contributors of the commits. The default collector is used if it is nil.
+	Contributors *contributors.Collector
+	// Parser parses the commit messages. commit_parser.Default() is used if it is nil.
+	Parser commit_parser.Parser
+	// Overrides replace the classification of single commits or exclude them. There are no overrides if it is nil.
+	Overrides *overrides.Overrides
+}
+
+// Creates the release notes of the commits. Non-conventional commits are skipped unless the layout contains a section
+// for other changes. Their first line becomes the description of the commit, which has no change type.
+func New(commits []*object.Commit, options Options) *ReleaseNotes {
+
+	if options.References == nil {
+		options.References = references.DefaultParser()
+	}
+
+	if options.Layout == nil {
+		options.Layout = DefaultLayout
+	}
+
+	if options.Contributors == nil {
+		options.Contributors = contributors.DefaultCollector()
+	}
+
+	options.Parser = commit_parser.OrDefault(options.Parser)
+
+	var parsedCommits []*Commit
+	var includedCommits []*object.Commit
+
+	for _, commit := range commits {
+		message, excluded, err := options.Overrides.Classify(commit, options.Parser)
+
+		if excluded {
+			logger.Logger.Debugln("Excluding commit", commit.Hash.String(), "by override")
+			continue
+		}
+
+		includedCommits = append(includedCommits, commit)
+
+		if err != nil {
+			if !options.Layout.includesNonConventionalCommits() {
+				logger.Logger.Debugln(err)
+				continue
+			}
+
+			message = nonConventionalCommitMessage(commit.Message)
+		}
+
+		parsedCommits = append(parsedCommits, &Commit{
+			ConventionalCommitMessage: message,
+			Hash:                      commit.Hash.String(),
+			ShortHash:                 commit.Hash.String()[:7],
+			Author:                    commit.Author.Name,
+			AuthorEmail:               commit.Author.Email,
+			Date:                      commit.Author.When,
+			References:                options.References.Parse(message),
+			gitCommit:                 commit,
+		})
+	}
+
+	notes := FromCommits(parsedCommits, options.Layout)
+	notes.Contributors = options.Contributors.Collect(includedCommits)
+
+	return notes
+}
+
+func nonConventionalCommitMessage(message string) *conventional_commits.ConventionalCommitMessage {
+
+	lines := strings.SplitN(strings.TrimSpace(message), "\n", 2)
+	commitMessage := &conventional_commits.ConventionalCommitMessage{Description: strings.TrimSpace(lines[0])}
+
+	if len(lines) > 1 {
+		commitMessage.Body = strings.TrimSpace(lines[1])
+	}
+
+	return commitMessage
+}
+
+// Creates the release notes of a release log including its version, tag and date.
+func FromReleaseLog(log *version_log.ReleaseLog, options Options) *ReleaseNotes {
+
+	notes := New(log.Commits, options)
+
+	if log.Version != nil {
+		notes.Version = log.Version.ToString()
+		notes.TagName = log.TagName
+		notes.Date = log.Date
+	}
+
+	return notes
+}
+
+// Adds the urls of the commits and references and the compare url between previousTagName and the tag of the release
+// notes (or HEAD for unreleased changes). The compare url is omitted if previousTagName is empty. The release notes
+// are not changed if l is nil.
+func (n *ReleaseNotes) AddLinks(l *links.Links, previousTagName string) {
+
+	if l == nil {
+		return
+	}
+
+	to := n.TagName
+
+	if to == "" {
+		to = "HEAD"
+	}
+
+	n.CompareURL = l.CompareURL(previousTagName, to)
+
+	for _, advisory := range n.Advisories {
+		advisory.URL = advisory.link()
+	}
+
+	for _, entry := range n.Commits {
+		for _, commit := range entry.AllCommits() {
+			commit.URL = l.CommitURL(commit.Hash)
+
+			for _, reference := range commit.References {
+				reference.AddLink(l)
+			}
+		}
+	}
+}
+
+// Creates the release notes of already parsed commits with the sections of the layout or of DefaultLayout if it is
+// nil. Duplicate commits are merged (see Commit.Duplicates). The contributors are empty, because they are collected
+// from the git commits.
+func FromCommits(commits []*Commit, layout *Layout) *ReleaseNotes {
+
+	if layout == nil {
+		layout = DefaultLayout
+	}
+
+	for _, commit := range commits {
+		commit.applyFooters()
+	}
+
+	allCommits := deduplicate(commits, layout.Deduplication)
+	commits = withoutSkipped(allCommits)
+
+	notes := &ReleaseNotes{
+		Commits:             append([]*Commit{}, commits...),
+		BreakingChanges:     append([]*BreakingChange{}, breakingChanges(allCommits, layout)...),
+		Notes:               notesOf(allCommits),
+		Advisories:          advisoriesOf(allCommits),
+		Sections:            []*Section{},
+		Authors:             []string{},
+		Issues:              []*references.Reference{},
+		Contributors:        []*contributors.Contributor{},
+		ContributorsSection: layout.Contributors,
+		ScopeGrouping:       layout.ScopeGrouping,
+	}
+
+	definitions := sectionDefinitions(commits, layout)
+
+	for _, definition := range definitions {
+		section := &Section{Types: append([]conventional_commits.ChangeType{}, definition.Types...), Title: definition.Title}
+
+		if !definition.Other && len(definition.Types) > 0 {
+			section.Type = definition.Types[0]
+		}
+
+		for _, commit := range commits {
+			// skip breaking changes without separate description, because they are listed as breaking changes
+			if sectionOf(commit, definitions, layout) != definition ||
+				commit.ContainsBreakingChange && len(commit.BreakingChangeDescriptions()) == 0 {
+				continue
+			}
+
+			section.Commits = append(section.Commits, commit)
+		}
+
+		if len(section.Commits) > 0 {
+			section.ScopeGroups = scopeGroups(section.Commits, layout)
+			notes.Sections = append(notes.Sections, section)
+		}
+	}
+
+	authors := make(map[string]bool)
+
+	for _, entry := range commits {
+		for _, commit := range entry.AllCommits() {
+			if commit.Author != "" && !authors[commit.Author] {
+				authors[commit.Author] = true
+				notes.Authors = append(notes.Authors, commit.Author)
+			}
+		}
+	}
+
+	issues := make(map[string]bool)
+
+	for _, commit := range commits {
+		for _, reference := range commit.References {
+			if reference.Type == references.ISSUE && !issues[reference.Text] {
+				issues[reference.Text] = true
+				notes.Issues = append(notes.Issues, reference)
+			}
+		}
+	}
+
+	return notes
+}
+
+// Groups the commits by scope. The groups are ordered by layout.ScopeOrder followed by the other groups in
+// alphabetical order. The group of commits without scope is last unless it is contained in layout.ScopeOrder.
+func scopeGroups(commits []*Commit, layout *Layout) []*ScopeGroup {
+
+	var groups []*ScopeGroup
+	groupsByTitle := make(map[string]*ScopeGroup)
+
+	for _, commit := range commits {
+		title := layout.scopeGroup(commit.Scope)
+		group, exists := groupsByTitle[title]
+
+		if !exists {
+			group = &ScopeGroup{Title: title}
+			groupsByTitle[title] = group
+			groups = append(groups, group)
+		}
+
+		group.Commits = append(group.Commits, commit)
+	}
+
+	rank := func(group *ScopeGroup) int {
+		for i, title := range layout.ScopeOrder {
+			if title == group.Title {
+				return i
+			}
+		}
+
+		if group.Title == layout.NoScope {
+			return len(layout.ScopeOrder) + 1
+		}
+
+		return len(layout.ScopeOrder)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		rankI, rankJ := rank(groups[i]), rank(groups[j])
+
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+
+		return groups[i].Title < groups[j].Title
+	})
+
+	return groups
+}
+
+// Returns the references, which are not part of the description (e.g. references in footers like "Refs: PROJ-123").
+func (c *Commit) AdditionalReferences() []*references.Reference {
+
+	var ret []*references.Reference
+
+	for _, reference := range c.References {
+		if reference.Source != references.DESCRIPTION {
+			ret = append(ret, reference)
+		}
+	}
+
+	return ret
+}
+
+// Returns the breaking changes ordered by the priorities of their change types. The sections of the layout define the
+// order of their change types.
+func breakingChanges(commits []*Commit, layout *Layout) []*BreakingChange {
+
+	var breakingCommits []*Commit
+
+	for _, commit := range commits {
+		if commit.ContainsBreakingChange {
+			breakingCommits = append(breakingCommits, commit)
+		}
+	}
+
+	priorities := conventional_commits.ChangeTypePrioritiesWithOrder(layout.Types())
+
+	sort.SliceStable(breakingCommits, func(i, j int) bool {
+		return priorities[breakingCommits[i].ChangeType] > priorities[breakingCommits[j].ChangeType]
+	})
+
+	var ret []*BreakingChange
+
+	for _, commit := range breakingCommits {
+		descriptions := commit.BreakingChangeDescriptions()
+
+		if len(descriptions) == 0 {
+			ret = append(ret, &BreakingChange{
+				Commit:      commit,
+				Scope:       commit.Scope,
+				Description: commit.Description,
+				Body:        commit.Body,
+			})
+			continue
+		}
+
+		for _, description := range descriptions {
+			ret = append(ret, &BreakingChange{
+				Commit:      commit,
+				Scope:       commit.Scope,
+				Description: description,
+			})
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,189 @@
+package release_notes
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/contributors"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/overrides"
+	"github.com/psanetra/git-semver/references"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func commit(changeType conventional_commits.ChangeType, scope string, description string, author string) *Commit {
+	return &Commit{
+		ConventionalCommitMessage: &conventional_commits.ConventionalCommitMessage{
+			ChangeType:  changeType,
+			Scope:       scope,
+			Description: description,
+		},
+		Author: author,
+	}
+}
+
+func render(t *testing.T, notes *ReleaseNotes, text string) string {
+	tmpl, err := ParseTemplate("test", text)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := notes.Render(tmpl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestFromCommits_should_group_sections_and_collect_authors(t *testing.T) {
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.FIX, "", "Fix", "bob"),
+		commit(conventional_commits.FEATURE, "", "Feature", "alice"),
+		commit(conventional_commits.CHORE, "", "Chore", "bob"),
+	}, nil)
+
+	assert.Len(t, notes.Sections, 2)
+	assert.Equal(t, "Features", notes.Sections[0].Title)
+	assert.Equal(t, "Bug Fixes", notes.Sections[1].Title)
+	assert.Equal(t, []string{"bob", "alice"}, notes.Authors)
+	assert.Len(t, notes.Commits, 3)
+}
+
+func TestMarkdownTemplate_should_render_sections_without_leading_empty_line(t *testing.T) {
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.FIX, "api", "Fix", ""),
+	}, nil)
+
+	assert.Equal(t, "### Bug Fixes\n\n* **api** Fix\n", render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestMarkdownTemplate_should_render_nothing_without_changes(t *testing.T) {
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.CHORE, "", "Chore", ""),
+	}, nil)
+
+	assert.Equal(t, "", render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestTemplate_should_provide_helper_functions(t *testing.T) {
+	notes := FromCommits([]*Commit{
+		commit(conventional_commits.FIX, "ui", "Fix *button*", ""),
+		commit(conventional_commits.FEATURE, "api", "Add endpoint", ""),
+		commit(conventional_commits.FIX, "api", "Fix <endpoint>", ""),
+	}, nil)
+	notes.Version = "1.2.0"
+	notes.Date = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	result := render(t, notes, `# {{ .Version }} ({{ date "2006-01-02" .Date }})
+{{ range groupBy "Scope" (sortBy "Scope" .Commits) }}
+## {{ .Key | upper }}
+{{ range .Commits }}- {{ .Description | escapeMarkdown }} / {{ .Description | escapeHTML }}
+{{ end }}{{ end }}`)
+
+	assert.Equal(t, `# 1.2.0 (2026-10-18)
+
+## API
+- Add endpoint / Add endpoint
+- Fix \<endpoint\> / Fix &lt;endpoint&gt;
+
+## UI
+- Fix \*button\* / Fix *button*
+`, result)
+}
+
+func TestTemplate_should_fail_on_unknown_field(t *testing.T) {
+	tmpl, err := ParseTemplate("test", `{{ groupBy "Unknown" .Commits }}`)
+	assert.Nil(t, err)
+
+	_, err = FromCommits(nil, nil).Render(tmpl)
+
+	assert.ErrorContains(t, err, "Unknown field Unknown")
+}
+
+func TestAddLinks_should_link_commits_references_and_versions(t *testing.T) {
+	fix := commit(conventional_commits.FIX, "", "Fix #12 and !3", "bob")
+	fix.Hash = "0123456789abcdef"
+	fix.ShortHash = "0123456"
+	fix.References = references.DefaultParser().Parse(fix.ConventionalCommitMessage)
+
+	notes := FromCommits([]*Commit{fix}, nil)
+	notes.TagName = "v1.1.0"
+
+	notes.AddLinks(&links.Links{
+		Commit:      "https://example.com/commit/{hash}",
+		Compare:     "https://example.com/compare/{from}...{to}",
+		Issue:       "https://example.com/issues/{id}",
+		PullRequest: "https://example.com/pulls/{id}",
+	}, "v1.0.0")
+
+	assert.Equal(t, "https://example.com/compare/v1.0.0...v1.1.0", notes.CompareURL)
+	assert.Equal(t, "https://example.com/commit/0123456789abcdef", fix.URL)
+	assert.Equal(t, "https://example.com/issues/12", fix.References[0].URL)
+	assert.Equal(t, "https://example.com/pulls/3", fix.References[1].URL)
+
+	assert.Equal(t, "### Bug Fixes\n\n* Fix [#12](https://example.com/issues/12) and [!3](https://example.com/pulls/3) ([0123456](https://example.com/commit/0123456789abcdef))\n", render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestLinkTemplateFunctions_should_escape_text(t *testing.T) {
+	notes := FromCommits([]*Commit{{
+		ConventionalCommitMessage: &conventional_commits.ConventionalCommitMessage{Description: "<b> #1"},
+		References:                []*references.Reference{{Type: references.ISSUE, ID: "1", Text: "#1", URL: "https://example.com/1"}},
+	}}, nil)
+
+	assert.Equal(t, `&lt;b&gt; <a href="https://example.com/1">#1</a>|a\_b|text`, render(t, notes, `{{ range .Commits }}{{ linkReferences "html" .Description .References }}{{ end }}|{{ link "rst" "a_b" "" }}|{{ link "text" "text" "https://example.com" }}`))
+}
+
+func TestMarkdownTemplate_should_render_additional_references(t *testing.T) {
+	fix := commit(conventional_commits.FIX, "", "Fix #1", "bob")
+	fix.References = []*references.Reference{
+		{Type: references.ISSUE, ID: "1", Text: "#1", Source: references.DESCRIPTION, URL: "https://example.com/1"},
+		{Type: references.ISSUE, ID: "PROJ-2", Text: "PROJ-2", Source: references.FOOTER, URL: "https://jira.example.com/PROJ-2"},
+		{Type: references.PULL_REQUEST, ID: "3", Text: "!3", Source: references.BODY},
+	}
+
+	notes := FromCommits([]*Commit{fix}, nil)
+
+	assert.Equal(t, "### Bug Fixes\n\n* Fix [#1](https://example.com/1), refs [PROJ-2](https://jira.example.com/PROJ-2), !3\n", render(t, notes, MARKDOWN_TEMPLATE))
+	assert.Equal(t, []*references.Reference{fix.References[0], fix.References[1]}, notes.Issues)
+}
+
+func TestMarkdownTemplate_should_render_contributors_section(t *testing.T) {
+	layout, err := NewLayout(config.ChangelogConfig{Contributors: config.ContributorsConfig{Section: true}})
+	assert.Nil(t, err)
+
+	notes := New([]*object.Commit{
+		{Author: object.Signature{Name: "Jane", Email: "jane@example.com"}, Message: "feat: Feature\n\nCo-authored-by: John <john@example.com>"},
+		{Author: object.Signature{Name: "Jane", Email: "jane@example.com"}, Message: "chore: Chore"},
+		{Author: object.Signature{Name: "dependabot[bot]", Email: "bot@example.com"}, Message: "chore(deps): Bump"},
+	}, Options{Layout: layout})
+
+	assert.Equal(t, "### Features\n\n* Feature\n\n### Contributors\n\n* Jane: 2 commits\n* John: 1 commit\n", render(t, notes, MARKDOWN_TEMPLATE))
+
+	notes.ContributorsSection = false
+
+	assert.Equal(t, "### Features\n\n* Feature\n", render(t, notes, MARKDOWN_TEMPLATE))
+}
+
+func TestNew_should_apply_overrides(t *testing.T) {
+	mistyped := &object.Commit{Hash: plumbing.NewHash("1111111111111111111111111111111111111111"), Message: "feat: Fix handler", Author: object.Signature{Name: "John"}}
+	nonConventional := &object.Commit{Hash: plumbing.NewHash("2222222222222222222222222222222222222222"), Message: "Add endpoint", Author: object.Signature{Name: "John"}}
+	excluded := &object.Commit{Hash: plumbing.NewHash("3333333333333333333333333333333333333333"), Message: "feat: Add internal api", Author: object.Signature{Name: "Jane"}}
+
+	commitOverrides, err := overrides.New(map[string]*overrides.Override{
+		"1111111": {Type: "fix"},
+		"2222222": {Type: "feat"},
+		"3333333": {Exclude: true},
+	})
+	assert.Nil(t, err)
+
+	notes := New([]*object.Commit{mistyped, nonConventional, excluded}, Options{Overrides: commitOverrides})
+
+	assert.Equal(t, "### Features\n\n* Add endpoint\n\n### Bug Fixes\n\n* Fix handler\n", render(t, notes, MARKDOWN_TEMPLATE))
+	assert.Equal(t, []*contributors.Contributor{{Name: "John", Commits: 2}}, notes.Contributors)
+}
@@ -0,0 +1,73 @@
+package release_notes
+
+import (
+	"strings"
+)
+
+type AdvisoryType string
+
+const (
+	// CVE advisories have IDs like "CVE-2024-12345"
+	CVE AdvisoryType = "cve"
+	// GHSA advisories are GitHub Security Advisories with IDs like "GHSA-8r8j-xvfj-36f9"
+	GHSA AdvisoryType = "ghsa"
+)
+
+// Advisory is a security advisory, which is referenced in the footers of security fixes (e.g. "Refs: CVE-2024-12345")
+type Advisory struct {
+	ID   string       `json:"id"`
+	Type AdvisoryType `json:"type"`
+	// URL links the advisory in the National Vulnerability Database or in the GitHub Advisory Database. It is empty if
+	// there are no links.
+	URL string `json:"url,omitempty"`
+	// Commits contain the hashes of the commits, which reference the advisory. Most recent commits are first.
+	Commits []string `json:"commits"`
+}
+
+// Sets the advisories of the commits and their duplicates and returns the distinct advisories ordered by their first
+// occurrence. Commits share the advisories with the same ID.
+func advisoriesOf(commits []*Commit) []*Advisory {
+
+	ret := []*Advisory{}
+	byID := make(map[string]*Advisory)
+
+	for _, entry := range commits {
+		for _, commit := range entry.AllCommits() {
+			commit.Advisories = nil
+
+			for _, id := range commit.ConventionalCommitMessage.Advisories() {
+				advisory, exists := byID[id]
+
+				if !exists {
+					advisory = &Advisory{ID: id, Type: advisoryType(id)}
+					byID[id] = advisory
+					ret = append(ret, advisory)
+				}
+
+				advisory.Commits = append(advisory.Commits, commit.Hash)
+				commit.Advisories = append(commit.Advisories, advisory)
+			}
+		}
+	}
+
+	return ret
+}
+
+func advisoryType(id string) AdvisoryType {
+
+	if strings.HasPrefix(id, "GHSA-") {
+		return GHSA
+	}
+
+	return CVE
+}
+
+// Returns the url of the advisory in the National Vulnerability Database or in the GitHub Advisory Database.
+func (a *Advisory) link() string {
+
+	if a.Type == GHSA {
+		return "https://github.com/advisories/" + a.ID
+	}
+
+	return "https://nvd.nist.gov/vuln/detail/" + a.ID
+}
@@ -0,0 +1,66 @@
+package release_notes
+
+import (
+	"encoding/json"
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/links"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFromCommits_should_list_security_fixes_first(t *testing.T) {
+	layout, err := NewLayout(config.ChangelogConfig{
+		Sections: []config.ChangelogSection{{Title: "Features", Types: []string{"feat"}}, {Title: "Bug Fixes", Types: []string{"fix"}}},
+		Hidden:   []string{"chore"},
+	})
+	assert.Nil(t, err)
+
+	feature := commit(conventional_commits.FEATURE, "", "Add endpoint", "alice")
+	security := commit(conventional_commits.SECURITY, "api", "Escape input", "bob")
+	security.Hash = "a1"
+	bump := withFooters(commit(conventional_commits.CHORE, "deps", "Bump yaml", "carol"), map[string][]string{"Refs": {"CVE-2024-12345, GHSA-8r8j-xvfj-36f9"}})
+	bump.Hash = "b2"
+	fix := withFooters(commit(conventional_commits.FIX, "", "Fix parser", "dave"), map[string][]string{"Refs": {"cve-2024-12345"}})
+	fix.Hash = "c3"
+
+	notes := FromCommits([]*Commit{feature, security, bump, fix}, layout)
+	notes.AddLinks(&links.Links{}, "")
+
+	assert.Equal(t, `### Security
+
+* **api** Escape input
+* **deps** Bump yaml, [CVE-2024-12345](https://nvd.nist.gov/vuln/detail/CVE-2024-12345), [GHSA-8r8j-xvfj-36f9](https://github.com/advisories/GHSA-8r8j-xvfj-36f9)
+* Fix parser, [CVE-2024-12345](https://nvd.nist.gov/vuln/detail/CVE-2024-12345)
+
+### Features
+
+* Add endpoint
+`, render(t, notes, MARKDOWN_TEMPLATE))
+
+	advisories, err := json.Marshal(notes.Advisories)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+  {"id": "CVE-2024-12345", "type": "cve", "url": "https://nvd.nist.gov/vuln/detail/CVE-2024-12345", "commits": ["b2", "c3"]},
+  {"id": "GHSA-8r8j-xvfj-36f9", "type": "ghsa", "url": "https://github.com/advisories/GHSA-8r8j-xvfj-36f9", "commits": ["b2"]}
+]`, string(advisories))
+}
+
+func TestNewLayout_should_use_configured_security_section(t *testing.T) {
+	layout, err := NewLayout(config.ChangelogConfig{Sections: []config.ChangelogSection{
+		{Title: "Features", Types: []string{"feat"}},
+		{Title: "Vulnerabilities", Types: []string{"security"}},
+	}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Features", "Vulnerabilities"}, []string{layout.Sections[0].Title, layout.Sections[1].Title})
+	assert.Equal(t, layout.Sections[1], layout.securitySection())
+
+	_, err = NewLayout(config.ChangelogConfig{Sections: []config.ChangelogSection{
+		{Title: "Security", Security: true},
+		{Title: "Vulnerabilities", Types: []string{"security"}},
+	}})
+
+	assert.EqualError(t, err, "Changelog section \"Vulnerabilities\" is not the only section for security fixes")
+}
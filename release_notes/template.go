@@ -0,0 +1,119 @@
+package release_notes
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"os"
+	"text/template"
+)
+
+// MARKDOWN_TEMPLATE is the default template
+const MARKDOWN_TEMPLATE = `
+{{- if .BreakingChanges -}}
+### BREAKING CHANGES
+
+{{ range .BreakingChanges -}}
+* {{ if .Scope }}**{{ .Scope }}** {{ end }}{{ linkReferences "markdown" .Description .Commit.References }}{{ with commitLinks "markdown" .Commit }} ({{ . }}){{ end }}
+{{ if .Body }}
+{{ .Body }}{{ end }}
+{{- end }}
+{{- end }}
+{{- if .Notes }}
+{{- if .BreakingChanges }}
+{{ end -}}
+### Release Notes
+
+{{ range .Notes -}}
+* {{ if .Commit.Scope }}**{{ .Commit.Scope }}** {{ end }}{{ linkReferences "markdown" .Text .Commit.References }}{{ with commitLinks "markdown" .Commit }} ({{ . }}){{ end }}
+{{ end }}
+{{- end }}
+{{- range $i, $section := .Sections }}
+{{- if or $i $.BreakingChanges $.Notes }}
+{{ end -}}
+### {{ $section.Title }}
+
+{{ if eq $.ScopeGrouping "headings" "bold" -}}
+{{ range $j, $group := $section.ScopeGroups -}}
+{{ if $j }}
+{{ end -}}
+{{ if eq $.ScopeGrouping "headings" }}#### {{ $group.Title }}{{ else }}**{{ $group.Title }}**{{ end }}
+
+{{ range $group.Commits }}* {{ template "markdown-entry" . }}{{ end }}
+{{- end }}
+{{- else -}}
+{{ range $section.Commits }}* {{ if .Scope }}**{{ .Scope }}** {{ end }}{{ template "markdown-entry" . }}{{ end }}
+{{- end }}
+{{- end }}
+{{- if and $.ContributorsSection $.Contributors }}
+{{- if or $.BreakingChanges $.Notes $.Sections }}
+{{ end -}}
+### Contributors
+
+{{ range $.Contributors }}* {{ .Name }}{{ with .Handle }} ({{ . }}){{ end }}: {{ .Commits }} {{ if eq .Commits 1 }}commit{{ else }}commits{{ end }}
+{{ end }}
+{{- end -}}
+
+{{- define "markdown-entry" -}}
+{{ linkReferences "markdown" .Description .References }}{{ with commitLinks "markdown" . }} ({{ . }}){{ end }}{{ range $i, $r := .AdditionalReferences }}{{ if $i }},{{ else }}, refs{{ end }} {{ link "markdown" $r.Text $r.URL }}{{ end }}{{ range .Advisories }}, {{ link "markdown" .ID .URL }}{{ end }}
+{{ if .Body }}{{ .Body }}
+{{ end }}
+{{- end -}}
+`
+
+// Renders the release notes of the commit messages with the default template and DefaultLayout, but without links.
+func ToMarkdown(messages []*conventional_commits.ConventionalCommitMessage) (string, error) {
+
+	var commits []*Commit
+
+	for _, message := range messages {
+		commits = append(commits, &Commit{ConventionalCommitMessage: message})
+	}
+
+	tmpl, err := LoadTemplate("")
+
+	if err != nil {
+		return "", err
+	}
+
+	return FromCommits(commits, nil).Render(tmpl)
+}
+
+// Parses a template for release notes and provides the helper functions of this package.
+func ParseTemplate(name string, text string) (*template.Template, error) {
+
+	tmpl, err := template.New(name).Funcs(TemplateFuncs).Parse(text)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not parse template "+name)
+	}
+
+	return tmpl, nil
+}
+
+// Loads a template from a file. The default template is returned if file is empty.
+func LoadTemplate(file string) (*template.Template, error) {
+
+	if file == "" {
+		return ParseTemplate("markdown", MARKDOWN_TEMPLATE)
+	}
+
+	content, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read template file")
+	}
+
+	return ParseTemplate(file, string(content))
+}
+
+func (n *ReleaseNotes) Render(tmpl *template.Template) (string, error) {
+
+	var buffer bytes.Buffer
+
+	if err := tmpl.Execute(&buffer, n); err != nil {
+		return "", errors.WithMessage(err, "Could not render release notes")
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,255 @@
+package release_notes
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/references"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// Group contains the commits with the same value of a field
+type Group struct {
+	Key     string
+	Commits []*Commit
+}
+
+// TemplateFuncs are available in all release notes templates
+var TemplateFuncs = template.FuncMap{
+	"groupBy":        groupBy,
+	"sortBy":         sortBy,
+	"escapeMarkdown": escapeMarkdown,
+	"escapeHTML":     html.EscapeString,
+	"indent":         indent,
+	"date":           date,
+	"join":           strings.Join,
+	"upper":          strings.ToUpper,
+	"lower":          strings.ToLower,
+	"replace":        strings.ReplaceAll,
+	"trim":           strings.TrimSpace,
+	"firstLine":      firstLine,
+	"escapeRST":      escapeRST,
+	"underline":      underline,
+	"link":           link,
+	"linkReferences": linkReferences,
+	"commitLinks":    commitLinks,
+}
+
+// commitFields are the fields, which can be used by groupBy and sortBy
+var commitFields = map[string]func(*Commit) string{
+	"Type":        func(c *Commit) string { return string(c.ChangeType) },
+	"Scope":       func(c *Commit) string { return c.Scope },
+	"Author":      func(c *Commit) string { return c.Author },
+	"Description": func(c *Commit) string { return c.Description },
+	"Date":        func(c *Commit) string { return c.Date.UTC().Format(time.RFC3339) },
+}
+
+// Groups the commits by a field (Type, Scope, Author, Description or Date). The groups are ordered by the first
+// occurrence of their key.
+func groupBy(field string, commits []*Commit) ([]*Group, error) {
+
+	value, ok := commitFields[field]
+
+	if !ok {
+		return nil, errors.Errorf("Unknown field %s", field)
+	}
+
+	var groups []*Group
+	groupsByKey := make(map[string]*Group)
+
+	for _, commit := range commits {
+		key := value(commit)
+		group, exists := groupsByKey[key]
+
+		if !exists {
+			group = &Group{Key: key}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+
+		group.Commits = append(group.Commits, commit)
+	}
+
+	return groups, nil
+}
+
+// Returns a copy of the commits, which is stably sorted by a field (Type, Scope, Author, Description or Date).
+func sortBy(field string, commits []*Commit) ([]*Commit, error) {
+
+	value, ok := commitFields[field]
+
+	if !ok {
+		return nil, errors.Errorf("Unknown field %s", field)
+	}
+
+	sorted := append([]*Commit{}, commits...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return value(sorted[i]) < value(sorted[j])
+	})
+
+	return sorted, nil
+}
+
+var markdownSpecialCharsRegex = regexp.MustCompile("([\\\\`*_{}\\[\\]<>()#+\\-.!|])")
+
+func escapeMarkdown(str string) string {
+	return markdownSpecialCharsRegex.ReplaceAllString(str, `\$1`)
+}
+
+var rstSpecialCharsRegex = regexp.MustCompile("([\\\\`*_|])")
+
+func escapeRST(str string) string {
+	return rstSpecialCharsRegex.ReplaceAllString(str, `\$1`)
+}
+
+// Indents all non-empty lines except the first line.
+func indent(spaces int, str string) string {
+	lines := strings.Split(str, "\n")
+
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", spaces) + lines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Returns a line of char with the length of title (e.g. for reStructuredText headings).
+func underline(char string, title string) string {
+	return strings.Repeat(char, utf8.RuneCountInString(title))
+}
+
+// Returns a link in the format (markdown | html | asciidoc | rst | text). The text is escaped for html and rst. The
+// text is returned without link if url is empty or the format is text.
+func link(format string, text string, url string) (string, error) {
+
+	escape, ok := escapers[Format(format)]
+
+	if !ok {
+		return "", errors.Errorf("Unknown link format %s", format)
+	}
+
+	if url == "" {
+		return escape(text), nil
+	}
+
+	switch Format(format) {
+	case MARKDOWN:
+		return "[" + text + "](" + url + ")", nil
+	case HTML:
+		return "<a href=\"" + html.EscapeString(url) + "\">" + html.EscapeString(text) + "</a>", nil
+	case ASCIIDOC:
+		return url + "[" + text + "]", nil
+	case RST:
+		return "`" + escapeRST(text) + " <" + url + ">`__", nil
+	}
+
+	return text, nil
+}
+
+// Returns the links of the commit and its duplicates in the format (see link), separated by commas. Returns an empty
+// string if the commit has no url.
+func commitLinks(format string, commit *Commit) (string, error) {
+
+	if commit.URL == "" {
+		return "", nil
+	}
+
+	var ret []string
+
+	for _, c := range commit.AllCommits() {
+		l, err := link(format, c.ShortHash, c.URL)
+
+		if err != nil {
+			return "", err
+		}
+
+		ret = append(ret, l)
+	}
+
+	return strings.Join(ret, ", "), nil
+}
+
+// Replaces the references in str with links in the format (see link). The remaining text is escaped like the text of
+// link.
+func linkReferences(format string, str string, refs []*references.Reference) (string, error) {
+
+	escape, ok := escapers[Format(format)]
+
+	if !ok {
+		return "", errors.Errorf("Unknown link format %s", format)
+	}
+
+	urls := make(map[string]string)
+	var texts []string
+
+	for _, reference := range refs {
+		if reference.URL != "" && urls[reference.Text] == "" {
+			urls[reference.Text] = reference.URL
+			texts = append(texts, regexp.QuoteMeta(reference.Text))
+		}
+	}
+
+	if len(texts) == 0 {
+		return escape(str), nil
+	}
+
+	// prefer longer references, which start with a shorter reference
+	sort.SliceStable(texts, func(i, j int) bool {
+		return len(texts[i]) > len(texts[j])
+	})
+
+	referencesRegex := regexp.MustCompile(`(?:^|[^\w&/])(` + strings.Join(texts, "|") + `)\b`)
+
+	var result strings.Builder
+	end := 0
+
+	for _, match := range referencesRegex.FindAllStringSubmatchIndex(str, -1) {
+		text := str[match[2]:match[3]]
+
+		linked, err := link(format, text, urls[text])
+
+		if err != nil {
+			return "", err
+		}
+
+		result.WriteString(escape(str[end:match[2]]))
+		result.WriteString(linked)
+		end = match[3]
+	}
+
+	result.WriteString(escape(str[end:]))
+
+	return result.String(), nil
+}
+
+// escapers escape the text of links
+var escapers = map[Format]func(string) string{
+	MARKDOWN: noEscape,
+	HTML:     html.EscapeString,
+	ASCIIDOC: noEscape,
+	RST:      escapeRST,
+	TEXT:     noEscape,
+}
+
+func noEscape(str string) string {
+	return str
+}
+
+func date(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
+
+func firstLine(str string) string {
+	return strings.SplitN(str, "\n", 2)[0]
+}
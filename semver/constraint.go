@@ -0,0 +1,196 @@
+package semver
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/regex_utils"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var constraintTermRegex = regexp.MustCompile(`^(?P<Operator>>=|<=|!=|>|<|=|\^|~)?v?(?P<Major>\d+)(\.(?P<Minor>\d+))?(\.(?P<Patch>\d+))?(?P<PreReleaseTagWithSeparator>-(?P<PreReleaseTag>[\dA-Za-z-]+(\.[\dA-Za-z-]+)*))?$`)
+var operatorSpaceRegex = regexp.MustCompile(`([<>=!^~])\s+`)
+
+// Constraint is a set of version ranges like ">=1.2.0 <2.0.0 || ^3.1".
+// Alternatives are separated by "||". The comparisons of an alternative are separated by spaces or commas.
+// Supported operators are =, !=, >, >=, <, <=, ^ (compatible major version) and ~ (compatible minor version).
+// Partial versions like "1.2" are completed like "1.2.x" (e.g. "<=1.2" matches 1.2.5, but "<1.2" does not).
+// Exclusive upper bounds exclude the pre-releases of the bound (e.g. "<2.0.0" and "^1" do not match 2.0.0-rc.1) unless
+// the bound is a pre-release itself (e.g. "<2.0.0-rc.2" matches 2.0.0-rc.1).
+type Constraint struct {
+	alternatives [][]comparison
+}
+
+type comparison struct {
+	operator string
+	version  *Version
+}
+
+func ParseConstraint(str string) (*Constraint, error) {
+
+	constraint := &Constraint{}
+
+	for _, alternative := range strings.Split(str, "||") {
+
+		var comparisons []comparison
+
+		// allow spaces between operator and version (e.g. ">= 1.2.0")
+		alternative = operatorSpaceRegex.ReplaceAllString(alternative, "$1")
+
+		for _, term := range strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' }) {
+			if term == "*" || term == "x" {
+				continue
+			}
+
+			termComparisons, err := parseConstraintTerm(term)
+
+			if err != nil {
+				return nil, errors.WithMessage(err, "Could not parse constraint "+str)
+			}
+
+			comparisons = append(comparisons, termComparisons...)
+		}
+
+		constraint.alternatives = append(constraint.alternatives, comparisons)
+	}
+
+	return constraint, nil
+}
+
+func parseConstraintTerm(term string) ([]comparison, error) {
+
+	submatches := regex_utils.SubmatchMap(constraintTermRegex, term)
+
+	if submatches == nil {
+		return nil, errors.Errorf("Invalid term \"%s\"", term)
+	}
+
+	// number of specified version components (1 to 3)
+	precision := 1
+	version := &Version{PreReleaseTag: []interface{}{}}
+	version.Major, _ = strconv.Atoi(submatches["Major"])
+
+	if submatches["Minor"] != "" {
+		precision++
+		version.Minor, _ = strconv.Atoi(submatches["Minor"])
+	}
+
+	if submatches["Patch"] != "" {
+		precision++
+		version.Patch, _ = strconv.Atoi(submatches["Patch"])
+	}
+
+	if submatches["PreReleaseTag"] != "" {
+		if precision < 3 {
+			return nil, errors.Errorf("Invalid term \"%s\": Pre-releases require a patch version", term)
+		}
+
+		version.PreReleaseTag, _ = parsePreReleaseTag(submatches["PreReleaseTag"])
+	}
+
+	// upper is the exclusive upper bound of the versions matching the partial version
+	upper := &Version{Major: version.Major + 1, PreReleaseTag: []interface{}{}}
+
+	if precision == 2 {
+		upper = &Version{Major: version.Major, Minor: version.Minor + 1, PreReleaseTag: []interface{}{}}
+	} else if precision == 3 {
+		upper = &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1, PreReleaseTag: []interface{}{}}
+	}
+
+	switch operator := submatches["Operator"]; operator {
+	case "", "=":
+		if precision == 3 {
+			return []comparison{{"=", version}}, nil
+		}
+
+		return []comparison{{">=", version}, {"<", upper}}, nil
+	case "!=":
+		if precision < 3 {
+			return nil, errors.Errorf("Invalid term \"%s\": != requires a complete version", term)
+		}
+
+		return []comparison{{"!=", version}}, nil
+	case ">":
+		if precision == 3 {
+			return []comparison{{">", version}}, nil
+		}
+
+		return []comparison{{">=", upper}}, nil
+	case "<=":
+		if precision == 3 {
+			return []comparison{{"<=", version}}, nil
+		}
+
+		return []comparison{{"<", upper}}, nil
+	case ">=", "<":
+		return []comparison{{operator, version}}, nil
+	case "^":
+		caretUpper := &Version{Major: version.Major + 1, PreReleaseTag: []interface{}{}}
+
+		if version.Major == 0 && precision >= 2 {
+			caretUpper = &Version{Minor: version.Minor + 1, PreReleaseTag: []interface{}{}}
+
+			if version.Minor == 0 && precision == 3 {
+				caretUpper = &Version{Patch: version.Patch + 1, PreReleaseTag: []interface{}{}}
+			}
+		}
+
+		return []comparison{{">=", version}, {"<", caretUpper}}, nil
+	default: // "~"
+		tildeUpper := &Version{Major: version.Major, Minor: version.Minor + 1, PreReleaseTag: []interface{}{}}
+
+		if precision == 1 {
+			tildeUpper = &Version{Major: version.Major + 1, PreReleaseTag: []interface{}{}}
+		}
+
+		return []comparison{{">=", version}, {"<", tildeUpper}}, nil
+	}
+}
+
+// Returns true if the version matches at least one alternative of the constraint.
+func (c *Constraint) Check(version *Version) bool {
+
+	for _, alternative := range c.alternatives {
+		if matchesAll(version, alternative) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if version is a pre-release of the release version.
+func isPreReleaseOf(version *Version, release *Version) bool {
+	return version.IsPreRelease() && !release.IsPreRelease() &&
+		version.Major == release.Major && version.Minor == release.Minor && version.Patch == release.Patch
+}
+
+func matchesAll(version *Version, comparisons []comparison) bool {
+
+	for _, c := range comparisons {
+		result := CompareVersions(version, c.version)
+
+		var matches bool
+
+		switch c.operator {
+		case "=":
+			matches = result == 0
+		case "!=":
+			matches = result != 0
+		case ">":
+			matches = result > 0
+		case ">=":
+			matches = result >= 0
+		case "<":
+			matches = result < 0 && !isPreReleaseOf(version, c.version)
+		case "<=":
+			matches = result <= 0
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertConstraint(t *testing.T, constraint string, matching []string, notMatching []string) {
+	c, err := ParseConstraint(constraint)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range matching {
+		version, err := ParseVersion(v)
+		assert.Nil(t, err)
+		assert.True(t, c.Check(version), "%s should match %s", v, constraint)
+	}
+
+	for _, v := range notMatching {
+		version, err := ParseVersion(v)
+		assert.Nil(t, err)
+		assert.False(t, c.Check(version), "%s should not match %s", v, constraint)
+	}
+}
+
+func TestConstraint_should_match_comparisons(t *testing.T) {
+	assertConstraint(t, ">=1.2.0 <2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"})
+	assertConstraint(t, ">= 1.2.0, < 2", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"})
+	assertConstraint(t, ">1.2.3", []string{"1.2.4"}, []string{"1.2.3"})
+	assertConstraint(t, "!=1.2.3", []string{"1.2.4"}, []string{"1.2.3"})
+	assertConstraint(t, "1.2.3-rc.1", []string{"1.2.3-rc.1"}, []string{"1.2.3", "1.2.3-rc.2"})
+}
+
+func TestConstraint_should_complete_partial_versions(t *testing.T) {
+	assertConstraint(t, "1.2", []string{"1.2.0", "1.2.9"}, []string{"1.1.9", "1.3.0"})
+	assertConstraint(t, "<=1.2", []string{"1.2.9"}, []string{"1.3.0"})
+	assertConstraint(t, "<1.2", []string{"1.1.9"}, []string{"1.2.0"})
+	assertConstraint(t, ">1", []string{"2.0.0"}, []string{"1.9.9"})
+	assertConstraint(t, "*", []string{"0.0.1", "9.9.9"}, nil)
+}
+
+func TestConstraint_should_match_caret_and_tilde(t *testing.T) {
+	assertConstraint(t, "^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"})
+	assertConstraint(t, "^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"})
+	assertConstraint(t, "^0.0.3", []string{"0.0.3"}, []string{"0.0.4"})
+	assertConstraint(t, "~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"})
+	assertConstraint(t, "~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"})
+}
+
+func TestConstraint_should_exclude_pre_releases_of_upper_bounds(t *testing.T) {
+	assertConstraint(t, "<2.0.0", []string{"1.9.9", "1.9.9-rc.1"}, []string{"2.0.0-rc.1", "2.0.0"})
+	assertConstraint(t, "^1", []string{"1.0.0", "1.5.0-rc.1"}, []string{"2.0.0-rc.1", "2.0.0-alpha"})
+	assertConstraint(t, "~1.2.3", []string{"1.2.9"}, []string{"1.3.0-rc.1"})
+	assertConstraint(t, "1.2", []string{"1.2.9"}, []string{"1.3.0-beta.1"})
+	assertConstraint(t, "<2.0.0-rc.2", []string{"2.0.0-rc.1"}, []string{"2.0.0-rc.2", "2.0.0"})
+	assertConstraint(t, "<=1.9.9 || >=2.0.0-rc.1 <2.0.0-rc.3", []string{"2.0.0-rc.2"}, []string{"2.0.0-rc.3"})
+}
+
+func TestConstraint_should_match_alternatives(t *testing.T) {
+	assertConstraint(t, "^1.2 || >=3.0.0", []string{"1.2.0", "3.1.0"}, []string{"2.0.0", "1.1.0"})
+}
+
+func TestParseConstraint_should_fail_on_invalid_terms(t *testing.T) {
+	_, err := ParseConstraint(">=1.2.0 <abc")
+
+	assert.EqualError(t, err, "Could not parse constraint >=1.2.0 <abc: Invalid term \"<abc\"")
+}
@@ -0,0 +1,224 @@
+package tag
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/logger"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+)
+
+const DEFAULT_MAX_ATTEMPTS = 5
+
+type TagOptions struct {
+	next.NextOptions
+	// Prefix is prepended to the version to build the tag name (e.g. "v")
+	Prefix string
+	// Remote is the name of a configured remote, which should receive the new tag. The tag is only created locally if it is empty.
+	// Only the new tag is pushed. Credentials are determined via git_utils.RemoteAuth.
+	Remote      string
+	MaxAttempts int
+}
+
+// Calculates the next version and tags HEAD with it.
+// The tag is only created if it does not exist yet (locally and on the remote). If another process created the
+// same tag in the meantime, the next version is recalculated and the tag creation is retried. This is only possible
+// for pre-releases with a counter. Releases fail deterministically.
+func Tag(options TagOptions) (*semver.Version, error) {
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	headRef, err := repo.Head()
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not find HEAD")
+	}
+
+	var auth transport.AuthMethod
+
+	if options.Remote != "" {
+		auth, err = git_utils.RemoteAuth(repo, options.Remote)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	maxAttempts := options.MaxAttempts
+
+	if maxAttempts < 1 {
+		maxAttempts = DEFAULT_MAX_ATTEMPTS
+	}
+
+	for attempt := 1; ; attempt++ {
+
+		nextVersion, err := next.Next(options.NextOptions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tagName := options.Prefix + nextVersion.ToString()
+
+		logger.Logger.Infof("Attempt %d/%d: Creating tag %s on commit %s", attempt, maxAttempts, tagName, headRef.Hash().String())
+
+		err = createTag(repo, options.Remote, auth, tagName, headRef.Hash())
+
+		if err == nil {
+			return nextVersion, nil
+		}
+
+		tagExistsErr, ok := err.(*TagExistsError)
+
+		if !ok {
+			return nil, err
+		}
+
+		if !options.PreReleaseOptions.AppendCounter {
+			return nil, errors.Errorf("%s. Retrying is only possible for pre-releases with a counter.", tagExistsErr)
+		}
+
+		if attempt >= maxAttempts {
+			return nil, errors.Errorf("%s. Giving up after %d attempts.", tagExistsErr, attempt)
+		}
+
+		logger.Logger.Infof("Attempt %d/%d: %s. Recalculating next version.", attempt, maxAttempts, tagExistsErr)
+
+		if options.Remote != "" {
+			if err = fetchTags(repo, options.Remote, auth); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// Indicates that a tag could not be created, because it exists already (locally or on the remote).
+type TagExistsError struct {
+	TagName string
+	// Remote is empty if the tag exists locally
+	Remote string
+	// Hash is the object the existing remote tag points to
+	Hash plumbing.Hash
+}
+
+func (e *TagExistsError) Error() string {
+	if e.Remote == "" {
+		return "Tag " + e.TagName + " already exists"
+	}
+
+	return "Remote " + e.Remote + " already has a conflicting tag " + e.TagName + " (" + e.Hash.String() + ")"
+}
+
+func createTag(repo *git.Repository, remoteName string, auth transport.AuthMethod, tagName string, hash plumbing.Hash) error {
+
+	ref, err := git_utils.CreateTagRef(repo, tagName, hash)
+
+	if err == git.ErrTagExists {
+		return &TagExistsError{TagName: tagName}
+	} else if err != nil {
+		return err
+	}
+
+	if remoteName == "" {
+		return nil
+	}
+
+	err = pushTag(repo, remoteName, auth, ref)
+
+	if err == nil {
+		return nil
+	}
+
+	if removeErr := git_utils.RemoveTagRef(repo, ref); removeErr != nil {
+		logger.Logger.Warnln("Could not remove local tag", tagName, "after failed push:", removeErr)
+	}
+
+	return err
+}
+
+func pushTag(repo *git.Repository, remoteName string, auth transport.AuthMethod, ref *plumbing.Reference) error {
+
+	remote, err := repo.Remote(remoteName)
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not find remote "+remoteName)
+	}
+
+	remoteRef, err := findRemoteRef(remote, auth, ref.Name())
+
+	if err != nil {
+		return err
+	}
+
+	if remoteRef != nil {
+		return &TagExistsError{TagName: ref.Name().Short(), Remote: remoteName, Hash: remoteRef.Hash()}
+	}
+
+	// The remote rejects the new tag if another process pushed it since it was listed
+	err = git_utils.PushNewRef(repo, remoteName, auth, ref)
+
+	if err == nil {
+		logger.Logger.Infoln("Pushed tag", ref.Name().Short(), "to remote", remoteName)
+		return nil
+	}
+
+	if remoteRef, listErr := findRemoteRef(remote, auth, ref.Name()); listErr == nil && remoteRef != nil {
+		logger.Logger.Debugln("Push of", ref.Name().Short(), "was rejected:", err)
+		return &TagExistsError{TagName: ref.Name().Short(), Remote: remoteName, Hash: remoteRef.Hash()}
+	}
+
+	return errors.WithMessage(err, "Could not push tag "+ref.Name().Short()+" to remote "+remoteName)
+}
+
+// Returns nil if the remote does not have the reference.
+func findRemoteRef(remote *git.Remote, auth transport.AuthMethod, refName plumbing.ReferenceName) (*plumbing.Reference, error) {
+
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+
+	if err == transport.ErrEmptyRemoteRepository {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "Could not list references of remote "+remote.Config().Name)
+	}
+
+	for _, ref := range refs {
+		if ref.Name() == refName {
+			return ref, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func fetchTags(repo *git.Repository, remoteName string, auth transport.AuthMethod) error {
+
+	err := repo.Fetch(&git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{"refs/tags/*:refs/tags/*"},
+		Tags:       git.NoTags,
+		Auth:       auth,
+	})
+
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	} else if err == git.ErrForceNeeded {
+		logger.Logger.Warnln("Some tags of remote", remoteName, "differ from local tags with the same name")
+		return nil
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Could not fetch tags from remote "+remoteName)
+	}
+
+	return nil
+}
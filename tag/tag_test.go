@@ -0,0 +1,122 @@
+package tag
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/psanetra/git-semver/next"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTag_should_create_tag_for_next_version(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+	hash := test_utils.Commit(t, repo, "feat: Add another feature")
+
+	version, err := Tag(TagOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1.0", version.ToString())
+
+	ref, err := repo.Tag("v1.1.0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
+
+func TestTag_should_fail_deterministically_if_release_tag_exists(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.Tag(t, repo, "v1.0.0")
+
+	_, err := Tag(TagOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+	})
+
+	assert.EqualError(t, err, "Tag v1.0.0 already exists. Retrying is only possible for pre-releases with a counter.")
+}
+
+func TestTag_should_push_tag_to_remote(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	remoteRepo, _ := test_utils.InitRemote(t, repo, "origin")
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+
+	version, err := Tag(TagOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Remote:      "origin",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", version.ToString())
+
+	ref, err := remoteRepo.Tag("v1.0.0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
+
+func TestTag_should_retry_with_next_pre_release_counter_if_remote_tag_exists(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	remoteRepo, _ := test_utils.InitRemote(t, repo, "origin")
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.PushBranches(t, repo, "origin")
+
+	// Simulates a concurrent pipeline, which already pushed the same pre-release
+	if err := remoteRepo.Storer.SetReference(plumbing.NewHashReference("refs/tags/v1.0.0-rc.1", hash)); err != nil {
+		t.Fatal(err)
+	}
+
+	version, err := Tag(TagOptions{
+		NextOptions: next.NextOptions{
+			Workdir:            dir,
+			Stable:             true,
+			MajorVersionFilter: -1,
+			PreReleaseOptions:  semver.PreReleaseOptions{Label: "rc", AppendCounter: true},
+		},
+		Prefix: "v",
+		Remote: "origin",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0-rc.2", version.ToString())
+
+	_, err = remoteRepo.Tag("v1.0.0-rc.2")
+	assert.Nil(t, err)
+
+	_, err = repo.Tag("v1.0.0-rc.1")
+	assert.Nil(t, err, "Conflicting tag should have been fetched")
+}
+
+func TestTag_should_fail_deterministically_if_remote_release_tag_exists(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+	remoteRepo, _ := test_utils.InitRemote(t, repo, "origin")
+
+	hash := test_utils.Commit(t, repo, "feat: Add feature")
+	test_utils.PushBranches(t, repo, "origin")
+
+	if err := remoteRepo.Storer.SetReference(plumbing.NewHashReference("refs/tags/v1.0.0", hash)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Tag(TagOptions{
+		NextOptions: next.NextOptions{Workdir: dir, Stable: true, MajorVersionFilter: -1},
+		Prefix:      "v",
+		Remote:      "origin",
+	})
+
+	assert.EqualError(t, err, "Remote origin already has a conflicting tag v1.0.0 ("+hash.String()+"). Retrying is only possible for pre-releases with a counter.")
+
+	_, err = repo.Tag("v1.0.0")
+	assert.NotNil(t, err, "Local tag should have been removed again")
+}
@@ -0,0 +1,263 @@
+package test_utils
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var Signature = object.Signature{
+	Name:  "testuser",
+	Email: "test@example.com",
+	When:  time.Date(2020, 6, 3, 20, 17, 23, 0, time.UTC),
+}
+
+// Creates a new non-bare git repository with a configured user in a temporary directory.
+func InitRepo(t *testing.T) (*git.Repository, string) {
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := repo.Config()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.User.Name = Signature.Name
+	cfg.User.Email = Signature.Email
+
+	if err = repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	return repo, dir
+}
+
+// Creates a new bare git repository in a temporary directory and adds it as remote to repo.
+func InitRemote(t *testing.T, repo *git.Repository, remoteName string) (*git.Repository, string) {
+	dir := t.TempDir()
+
+	remoteRepo, err := git.PlainInit(dir, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: remoteName,
+		URLs: []string{dir},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return remoteRepo, dir
+}
+
+// Adds a new file and commits it with the given message. Commits get increasing commit dates.
+func Commit(t *testing.T, repo *git.Repository, message string) plumbing.Hash {
+	worktree, err := repo.Worktree()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := repo.Head()
+	count := 0
+
+	if head != nil {
+		commits, err := repo.Log(&git.LogOptions{From: head.Hash()})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = commits.ForEach(func(*object.Commit) error {
+			count++
+			return nil
+		})
+	}
+
+	fileName := "file" + strconv.Itoa(count) + ".txt"
+
+	WriteFile(t, repo, fileName, message)
+
+	signature := Signature
+	signature.When = signature.When.Add(time.Duration(count) * time.Minute)
+
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		All:       true,
+		Author:    &signature,
+		Committer: &signature,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+// Writes a file into the worktree of repo and stages it.
+func WriteFile(t *testing.T, repo *git.Repository, fileName string, content string) {
+	worktree, err := repo.Worktree()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(worktree.Filesystem.Root(), fileName)
+
+	if err = os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(path, []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = worktree.Add(fileName); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Creates a lightweight tag on HEAD.
+func Tag(t *testing.T, repo *git.Repository, tagName string) {
+	head, err := repo.Head()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = repo.CreateTag(tagName, head.Hash(), nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Creates an annotated tag on HEAD.
+func AnnotatedTag(t *testing.T, repo *git.Repository, tagName string, message string) {
+	head, err := repo.Head()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{
+		Tagger:  &Signature,
+		Message: message,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Pushes all local branches to the remote.
+func PushBranches(t *testing.T, repo *git.Repository, remoteName string) {
+	err := repo.Push(&git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Adds a note with the content to the commit in notesRef (e.g. "refs/notes/commits") like "git notes add".
+func Note(t *testing.T, repo *git.Repository, notesRef string, commit plumbing.Hash, content string) {
+	var entries []object.TreeEntry
+	var parents []plumbing.Hash
+
+	if ref, err := repo.Reference(plumbing.ReferenceName(notesRef), true); err == nil {
+		parent, err := repo.CommitObject(ref.Hash())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tree, err := parent.Tree()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, entry := range tree.Entries {
+			if entry.Name != commit.String() {
+				entries = append(entries, entry)
+			}
+		}
+
+		parents = append(parents, ref.Hash())
+	}
+
+	blob := repo.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	blobHash, err := repo.Storer.SetEncodedObject(blob)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries = append(entries, object.TreeEntry{Name: commit.String(), Mode: filemode.Regular, Hash: blobHash})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	treeHash := storeObject(t, repo, &object.Tree{Entries: entries})
+	notesCommit := storeObject(t, repo, &object.Commit{
+		Author:       Signature,
+		Committer:    Signature,
+		Message:      "Notes added by 'git notes add'",
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	})
+
+	if err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(notesRef), notesCommit)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func storeObject(t *testing.T, repo *git.Repository, obj interface {
+	Encode(plumbing.EncodedObject) error
+}) plumbing.Hash {
+	encoded := repo.Storer.NewEncodedObject()
+
+	if err := obj.Encode(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := repo.Storer.SetEncodedObject(encoded)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
@@ -0,0 +1,63 @@
+package upgrade_guide
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/release_notes"
+)
+
+// MARKDOWN_TEMPLATE renders upgrade guides in markdown
+const MARKDOWN_TEMPLATE = `# Upgrade from {{ .From }} to {{ .To }}
+{{ if not .Versions }}
+There are no breaking changes or deprecations.
+{{ end }}
+{{- range .Versions }}
+## {{ link "markdown" .Version .CompareURL }}{{ with date "2006-01-02" .Date }} ({{ . }}){{ end }}
+{{ range .Scopes }}
+### {{ .Title }}
+{{ with .BreakingChanges }}
+#### Breaking Changes
+
+{{ range . }}* {{ linkReferences "markdown" .Description .Commit.References }}{{ with commitLinks "markdown" .Commit }} ({{ . }}){{ end }}
+{{ with .Body }}  {{ indent 2 . }}
+{{ end }}{{ end }}{{ end }}
+{{- with .Deprecations }}
+#### Deprecations
+
+{{ range . }}* {{ linkReferences "markdown" .Description .Commit.References }}{{ with commitLinks "markdown" .Commit }} ({{ . }}){{ end }}
+{{ end }}{{ end }}
+{{- end }}
+{{- end -}}
+`
+
+// Renders the upgrade guide in markdown or json.
+func (g *UpgradeGuide) Render(format release_notes.Format) (string, error) {
+
+	switch format {
+	case release_notes.MARKDOWN:
+		tmpl, err := release_notes.ParseTemplate("upgrade-guide", MARKDOWN_TEMPLATE)
+
+		if err != nil {
+			return "", err
+		}
+
+		var buffer bytes.Buffer
+
+		if err = tmpl.Execute(&buffer, g); err != nil {
+			return "", errors.WithMessage(err, "Could not render upgrade guide")
+		}
+
+		return buffer.String(), nil
+	case release_notes.JSON:
+		result, err := json.MarshalIndent(g, "", "  ")
+
+		if err != nil {
+			return "", errors.WithMessage(err, "Could not marshal json")
+		}
+
+		return string(result) + "\n", nil
+	}
+
+	return "", errors.Errorf("Unknown format \"%s\" (expected %s or %s)", format, release_notes.MARKDOWN, release_notes.JSON)
+}
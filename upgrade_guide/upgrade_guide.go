@@ -0,0 +1,181 @@
+package upgrade_guide
+
+import (
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/links"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/version_log"
+	"sort"
+	"time"
+)
+
+// UpgradeGuide contains the breaking changes and deprecations of all versions between two versions
+type UpgradeGuide struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Versions contain the versions with breaking changes or deprecations in the order of the upgrade (lowest version
+	// first)
+	Versions []*Version `json:"versions"`
+}
+
+// Version contains the breaking changes and deprecations introduced by a version
+type Version struct {
+	Version string    `json:"version"`
+	TagName string    `json:"tag_name"`
+	Date    time.Time `json:"date"`
+	// CompareURL links the changes between the previous and this version. It is empty if there are no links.
+	CompareURL string `json:"compare_url,omitempty"`
+	// Scopes are ordered alphabetically. The group of changes without scope is last.
+	Scopes []*ScopeGroup `json:"scopes"`
+}
+
+// ScopeGroup contains the breaking changes and deprecations of a scope
+type ScopeGroup struct {
+	// Scope is empty for changes without scope
+	Scope string `json:"scope,omitempty"`
+	// Title is the scope or the title of the layout for changes without scope
+	Title           string                          `json:"title"`
+	BreakingChanges []*release_notes.BreakingChange `json:"breaking_changes"`
+	Deprecations    []*Deprecation                  `json:"deprecations"`
+}
+
+// Deprecation is a deprecation notice of a commit (e.g. "DEPRECATED: Use --out instead of --output")
+type Deprecation struct {
+	Commit      *release_notes.Commit `json:"commit"`
+	Scope       string                `json:"scope,omitempty"`
+	Description string                `json:"description"`
+}
+
+// Options configure the creation of upgrade guides
+type Options struct {
+	Workdir string
+	// From is the current version. Its changes are not contained in the upgrade guide.
+	From *semver.Version
+	// To is the target version. It must be tagged.
+	To *semver.Version
+	// Ignore removes matching commits. No commits are ignored if it is nil.
+	Ignore *ignore.Rules
+	// ReleaseNotes configure the parsing of the commits of each version
+	ReleaseNotes release_notes.Options
+	// Links are used to link commits, issues and versions. There are no links if it is nil.
+	Links *links.Links
+}
+
+// Creates the upgrade guide from options.From to options.To. It contains the breaking changes (BREAKING CHANGE footers
+// and "!" commits) and the deprecation notices of all versions, which are greater than From and not greater than To.
+// The commits of pre-releases are contained in the following release.
+func New(options Options) (*UpgradeGuide, error) {
+
+	if semver.CompareVersions(options.From, options.To) >= 0 {
+		return nil, errors.Errorf("Version %s is not greater than %s", options.To.ToString(), options.From.ToString())
+	}
+
+	logs, err := version_log.ReleaseLogs(version_log.ReleaseLogsOptions{
+		Workdir: options.Workdir,
+		Since:   options.From,
+		Ignore:  options.Ignore,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	guide := &UpgradeGuide{
+		From:     options.From.ToString(),
+		To:       options.To.ToString(),
+		Versions: []*Version{},
+	}
+
+	noScope := release_notes.DEFAULT_NO_SCOPE
+
+	if options.ReleaseNotes.Layout != nil {
+		noScope = options.ReleaseNotes.Layout.NoScope
+	}
+
+	toIsTagged := false
+
+	// logs are ordered by descending versions
+	for i := len(logs) - 1; i >= 0; i-- {
+		log := logs[i]
+
+		if log.Version == nil || semver.CompareVersions(log.Version, options.From) <= 0 || semver.CompareVersions(log.Version, options.To) > 0 {
+			continue
+		}
+
+		toIsTagged = toIsTagged || semver.CompareVersions(log.Version, options.To) == 0
+
+		notes := release_notes.FromReleaseLog(log, options.ReleaseNotes)
+		notes.AddLinks(options.Links, log.PreviousTagName)
+
+		version := &Version{
+			Version:    notes.Version,
+			TagName:    notes.TagName,
+			Date:       notes.Date,
+			CompareURL: notes.CompareURL,
+			Scopes:     scopeGroups(notes, noScope),
+		}
+
+		if len(version.Scopes) > 0 {
+			guide.Versions = append(guide.Versions, version)
+		}
+	}
+
+	if !toIsTagged {
+		return nil, errors.Errorf("Version %s is not released (there is no tag for it)", options.To.ToString())
+	}
+
+	return guide, nil
+}
+
+// Groups the breaking changes and the deprecations of the release notes by scope.
+func scopeGroups(notes *release_notes.ReleaseNotes, noScope string) []*ScopeGroup {
+
+	var groups []*ScopeGroup
+	groupsByScope := make(map[string]*ScopeGroup)
+
+	group := func(scope string) *ScopeGroup {
+		g, exists := groupsByScope[scope]
+
+		if !exists {
+			g = &ScopeGroup{
+				Scope:           scope,
+				Title:           scope,
+				BreakingChanges: []*release_notes.BreakingChange{},
+				Deprecations:    []*Deprecation{},
+			}
+
+			if scope == "" {
+				g.Title = noScope
+			}
+
+			groupsByScope[scope] = g
+			groups = append(groups, g)
+		}
+
+		return g
+	}
+
+	for _, breakingChange := range notes.BreakingChanges {
+		g := group(breakingChange.Scope)
+		g.BreakingChanges = append(g.BreakingChanges, breakingChange)
+	}
+
+	for _, commit := range notes.Commits {
+		for _, description := range commit.DeprecationDescriptions() {
+			g := group(commit.Scope)
+			g.Deprecations = append(g.Deprecations, &Deprecation{Commit: commit, Scope: commit.Scope, Description: description})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Scope == "" || groups[j].Scope == "" {
+			return groups[j].Scope == "" && groups[i].Scope != ""
+		}
+
+		return groups[i].Scope < groups[j].Scope
+	})
+
+	return groups
+}
@@ -0,0 +1,162 @@
+package upgrade_guide
+
+import (
+	"encoding/json"
+	"github.com/psanetra/git-semver/release_notes"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func version(t *testing.T, str string) *semver.Version {
+	v, err := semver.ParseVersion(str)
+	assert.Nil(t, err)
+	return v
+}
+
+func TestNew_should_collect_breaking_changes_and_deprecations_of_all_versions(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add items")
+	test_utils.Tag(t, repo, "v1.3.0")
+	test_utils.Commit(t, repo, "feat(api)!: Replace items endpoint\n\nBREAKING CHANGE: Use /v2/items instead of /items.")
+	test_utils.Commit(t, repo, "feat(cli): Add --out flag\n\nDEPRECATED: Use --out instead of --output.")
+	test_utils.Tag(t, repo, "v2.0.0")
+	test_utils.Commit(t, repo, "fix: Fix crash")
+	test_utils.Tag(t, repo, "v2.0.1")
+	test_utils.Commit(t, repo, "feat!: Drop support for Go 1.20")
+	test_utils.Tag(t, repo, "v3.0.0-rc.1")
+	test_utils.Commit(t, repo, "feat(cli)!: Remove --output flag\n\nThe flag was deprecated in 2.0.0.")
+	test_utils.Tag(t, repo, "v3.0.0")
+	test_utils.Commit(t, repo, "feat(api)!: Remove /items")
+	test_utils.Tag(t, repo, "v4.0.0")
+
+	guide, err := New(Options{Workdir: dir, From: version(t, "1.3.0"), To: version(t, "3.0.0")})
+
+	assert.Nil(t, err)
+
+	output, err := guide.Render(release_notes.MARKDOWN)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `# Upgrade from 1.3.0 to 3.0.0
+
+## 2.0.0 (2020-06-03)
+
+### api
+
+#### Breaking Changes
+
+* Use /v2/items instead of /items.
+
+### cli
+
+#### Deprecations
+
+* Use --out instead of --output.
+
+## 3.0.0 (2020-06-03)
+
+### cli
+
+#### Breaking Changes
+
+* Remove --output flag
+  The flag was deprecated in 2.0.0.
+
+### Other
+
+#### Breaking Changes
+
+* Drop support for Go 1.20
+`, output)
+
+	output, err = guide.Render(release_notes.JSON)
+
+	assert.Nil(t, err)
+
+	var result map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, "1.3.0", result["from"])
+	assert.Len(t, result["versions"], 2)
+}
+
+func TestNew_should_fail_on_invalid_spans(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add items")
+	test_utils.Tag(t, repo, "v1.0.0")
+
+	_, err := New(Options{Workdir: dir, From: version(t, "1.0.0"), To: version(t, "1.0.0")})
+
+	assert.EqualError(t, err, "Version 1.0.0 is not greater than 1.0.0")
+
+	_, err = New(Options{Workdir: dir, From: version(t, "1.0.0"), To: version(t, "2.0.0")})
+
+	assert.EqualError(t, err, "Version 2.0.0 is not released (there is no tag for it)")
+}
+
+func TestNew_should_render_guides_without_changes(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add items")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "fix: Fix crash")
+	test_utils.Tag(t, repo, "v1.0.1")
+
+	guide, err := New(Options{Workdir: dir, From: version(t, "1.0.0"), To: version(t, "1.0.1")})
+
+	assert.Nil(t, err)
+
+	output, err := guide.Render(release_notes.MARKDOWN)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "# Upgrade from 1.0.0 to 1.0.1\n\nThere are no breaking changes or deprecations.\n", output)
+
+	_, err = guide.Render(release_notes.HTML)
+
+	assert.EqualError(t, err, "Unknown format \"html\" (expected markdown or json)")
+}
+
+func TestParseVersions_should_resolve_partial_versions(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: Add items")
+	test_utils.Tag(t, repo, "v1.3.0")
+	test_utils.Commit(t, repo, "feat!: Remove items")
+	test_utils.Tag(t, repo, "v4.1.0")
+	test_utils.Commit(t, repo, "fix: Fix crash")
+	test_utils.Tag(t, repo, "v4.1.1")
+	test_utils.Commit(t, repo, "fix: Fix another crash")
+	test_utils.Tag(t, repo, "v4.1.2-rc.1")
+	test_utils.Commit(t, repo, "feat: Add endpoint")
+	test_utils.Tag(t, repo, "v4.2.0")
+
+	from, to, err := ParseVersions(dir, "1.3", "4.1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.3.0", from.ToString())
+	assert.Equal(t, "4.1.1", to.ToString())
+
+	guide, err := New(Options{Workdir: dir, From: from, To: to})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "4.1.1", guide.To)
+	assert.Len(t, guide.Versions, 1)
+
+	from, to, err = ParseVersions(dir, "1", "4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", from.ToString())
+	assert.Equal(t, "4.2.0", to.ToString())
+
+	from, to, err = ParseVersions(dir, "1.3.0", "4.1.1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.3.0", from.ToString())
+	assert.Equal(t, "4.1.1", to.ToString())
+
+	_, _, err = ParseVersions(dir, "1.3", "5.0")
+
+	assert.EqualError(t, err, "Could not find a released version matching 5.0")
+}
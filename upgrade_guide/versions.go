@@ -0,0 +1,91 @@
+package upgrade_guide
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/semver"
+	"regexp"
+	"strconv"
+)
+
+// partialVersionRegex matches versions without minor or patch version (e.g. "1.3" or "v4")
+var partialVersionRegex = regexp.MustCompile(`^v?(?P<Major>\d+)(\.(?P<Minor>\d+))?$`)
+
+// Parses the versions of an upgrade guide. They may be partial versions like "1.3" or "4". A partial from version is
+// completed with zeros (e.g. 1.3.0). A partial to version is resolved to the greatest released version tag with its
+// major and minor version (e.g. 4.1.2 for 4.1).
+func ParseVersions(workdir string, from string, to string) (*semver.Version, *semver.Version, error) {
+
+	fromVersion, err := parseVersion(from)
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not parse version "+from)
+	}
+
+	toMatch := partialVersionRegex.FindStringSubmatch(to)
+
+	if toMatch == nil {
+		toVersion, err := semver.ParseVersion(to)
+
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "Could not parse version "+to)
+		}
+
+		return fromVersion, toVersion, nil
+	}
+
+	repo, err := git.PlainOpenWithOptions(workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	versions, err := git_utils.GetVersions(repo)
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Could not find Tags")
+	}
+
+	major, _ := strconv.Atoi(toMatch[partialVersionRegex.SubexpIndex("Major")])
+	minor := -1
+
+	if minorStr := toMatch[partialVersionRegex.SubexpIndex("Minor")]; minorStr != "" {
+		minor, _ = strconv.Atoi(minorStr)
+	}
+
+	var toVersion *semver.Version
+
+	for _, version := range versions {
+		if version.IsPreRelease() || version.Major != major || minor >= 0 && version.Minor != minor {
+			continue
+		}
+
+		if toVersion == nil || semver.CompareVersions(version, toVersion) > 0 {
+			toVersion = version
+		}
+	}
+
+	if toVersion == nil {
+		return nil, nil, errors.Errorf("Could not find a released version matching %s", to)
+	}
+
+	return fromVersion, toVersion, nil
+}
+
+// Parses a version, which may be a partial version without minor or patch version.
+func parseVersion(str string) (*semver.Version, error) {
+
+	match := partialVersionRegex.FindStringSubmatch(str)
+
+	if match == nil {
+		return semver.ParseVersion(str)
+	}
+
+	major, _ := strconv.Atoi(match[partialVersionRegex.SubexpIndex("Major")])
+	minor, _ := strconv.Atoi(match[partialVersionRegex.SubexpIndex("Minor")])
+
+	return &semver.Version{Major: major, Minor: minor}, nil
+}
@@ -0,0 +1,208 @@
+package version_log
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/commit_parser"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/overrides"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Filter selects commits of a log. Empty fields match all commits. The filters on types, scopes and breaking changes
+// only match conventional commits.
+type Filter struct {
+	// Types of the conventional commits (e.g. "feat" or "fix")
+	Types []conventional_commits.ChangeType
+	// Scopes are glob patterns (e.g. "api*") matching the scopes of the conventional commits
+	Scopes []string
+	// BreakingOnly selects only conventional commits with breaking changes
+	BreakingOnly bool
+	// Author is a regex matching "Name <email>" of the author
+	Author string
+	// Paths select commits, which changed files in these directories or files matching these glob patterns. The paths
+	// are relative to the root of the repository.
+	Paths []string
+	// Grep is a regex matching the description of conventional commits or the first line of other commits
+	Grep string
+	// Parser parses the commit messages. commit_parser.Default() is used if it is nil.
+	Parser commit_parser.Parser
+	// Overrides replace the classification of single commits. Excluded commits are no conventional commits. There are
+	// no overrides if it is nil.
+	Overrides *overrides.Overrides
+}
+
+// Returns true if the filter is nil or does not filter any commits.
+func (f *Filter) isEmpty() bool {
+	return f == nil ||
+		len(f.Types) == 0 && len(f.Scopes) == 0 && !f.BreakingOnly && f.Author == "" && len(f.Paths) == 0 && f.Grep == ""
+}
+
+// Returns the commits matching all fields of the filter.
+func filterCommits(commits []*object.Commit, filter *Filter) ([]*object.Commit, error) {
+
+	if filter.isEmpty() {
+		return commits, nil
+	}
+
+	for _, pattern := range append(append([]string{}, filter.Scopes...), filter.Paths...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.WithMessage(err, "Could not parse pattern "+pattern)
+		}
+	}
+
+	var authorRegex, grepRegex *regexp.Regexp
+	var err error
+
+	if filter.Author != "" {
+		if authorRegex, err = regexp.Compile(filter.Author); err != nil {
+			return nil, errors.WithMessage(err, "Could not parse author pattern "+filter.Author)
+		}
+	}
+
+	if filter.Grep != "" {
+		if grepRegex, err = regexp.Compile(filter.Grep); err != nil {
+			return nil, errors.WithMessage(err, "Could not parse grep pattern "+filter.Grep)
+		}
+	}
+
+	requiresConventionalCommit := len(filter.Types) > 0 || len(filter.Scopes) > 0 || filter.BreakingOnly
+
+	var ret []*object.Commit
+
+	for _, commit := range commits {
+		message, excluded, parseErr := filter.Overrides.Classify(commit, filter.Parser)
+
+		if (excluded || parseErr != nil) && requiresConventionalCommit {
+			continue
+		}
+
+		if message != nil && !filter.matchesConventionalCommit(message) {
+			continue
+		}
+
+		if authorRegex != nil && !authorRegex.MatchString(commit.Author.Name+" <"+commit.Author.Email+">") {
+			continue
+		}
+
+		if grepRegex != nil {
+			description := strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0])
+
+			if message != nil {
+				description = message.Description
+			}
+
+			if !grepRegex.MatchString(description) {
+				continue
+			}
+		}
+
+		if len(filter.Paths) > 0 {
+			matches, err := touchesPaths(commit, filter.Paths)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if !matches {
+				continue
+			}
+		}
+
+		ret = append(ret, commit)
+	}
+
+	return ret, nil
+}
+
+func (f *Filter) matchesConventionalCommit(message *conventional_commits.ConventionalCommitMessage) bool {
+
+	if f.BreakingOnly && !message.ContainsBreakingChange {
+		return false
+	}
+
+	if len(f.Types) > 0 && !containsType(f.Types, message.ChangeType) {
+		return false
+	}
+
+	if len(f.Scopes) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.Scopes {
+		if matches, _ := path.Match(pattern, message.Scope); matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsType(types []conventional_commits.ChangeType, changeType conventional_commits.ChangeType) bool {
+	for _, t := range types {
+		if strings.EqualFold(string(t), string(changeType)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if the commit changed a file in one of the paths compared to its first parent. All files of root
+// commits are changed.
+func touchesPaths(commit *object.Commit, paths []string) (bool, error) {
+
+	tree, err := commit.Tree()
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Could not read tree of commit "+commit.Hash.String())
+	}
+
+	var parentTree *object.Tree
+
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+
+		if err != nil {
+			return false, errors.WithMessage(err, "Could not read parent of commit "+commit.Hash.String())
+		}
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return false, errors.WithMessage(err, "Could not read tree of commit "+parent.Hash.String())
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Could not diff commit "+commit.Hash.String())
+	}
+
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" && matchesPath(name, paths) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func matchesPath(file string, paths []string) bool {
+	for _, p := range paths {
+		p = path.Clean(strings.TrimPrefix(p, "/"))
+
+		if p == "." || file == p || strings.HasPrefix(file, p+"/") {
+			return true
+		}
+
+		if matches, _ := path.Match(p, file); matches {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package version_log
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/conventional_commits"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/overrides"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func initFilterHistory(t *testing.T) string {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "chore: Init")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.WriteFile(t, repo, "api/handler.go", "package api")
+	test_utils.Commit(t, repo, "feat(api)!: Replace handler")
+	test_utils.WriteFile(t, repo, "ui/button.ts", "button")
+	test_utils.Commit(t, repo, "fix(ui-button): Fix button")
+	test_utils.WriteFile(t, repo, "api/handler.go", "package api // fixed")
+	test_utils.Commit(t, repo, "fix(api): Fix handler")
+	test_utils.Commit(t, repo, "Update readme")
+
+	return dir
+}
+
+func filteredLog(t *testing.T, dir string, filter *Filter) []string {
+	commits, err := VersionLog(VersionLogOptions{Workdir: dir, Filter: filter})
+	assert.Nil(t, err)
+
+	return messages(commits)
+}
+
+func TestVersionLog_should_filter_conventional_commit_fields(t *testing.T) {
+	dir := initFilterHistory(t)
+
+	assert.Equal(t, []string{"fix(api): Fix handler", "fix(ui-button): Fix button"}, filteredLog(t, dir, &Filter{Types: []conventional_commits.ChangeType{conventional_commits.FIX}}))
+	assert.Equal(t, []string{"fix(ui-button): Fix button"}, filteredLog(t, dir, &Filter{Scopes: []string{"ui*"}}))
+	assert.Equal(t, []string{"feat(api)!: Replace handler"}, filteredLog(t, dir, &Filter{BreakingOnly: true}))
+	assert.Equal(t, []string{"fix(api): Fix handler"}, filteredLog(t, dir, &Filter{Types: []conventional_commits.ChangeType{conventional_commits.FIX}, Scopes: []string{"api"}}))
+}
+
+func TestVersionLog_should_filter_commits_classified_by_overrides(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "chore: Init")
+	test_utils.Tag(t, repo, "v1.0.0")
+	feature := test_utils.Commit(t, repo, "Add endpoint")
+	fix := test_utils.Commit(t, repo, "fix: Fix endpoint")
+	excluded := test_utils.Commit(t, repo, "fix: Fix typo")
+
+	commitOverrides, err := overrides.New(map[string]*overrides.Override{
+		feature.String():  {Type: "feat"},
+		fix.String():      {Type: "feat"},
+		excluded.String(): {Exclude: true},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"fix: Fix endpoint", "Add endpoint"}, filteredLog(t, dir, &Filter{Types: []conventional_commits.ChangeType{conventional_commits.FEATURE}, Overrides: commitOverrides}))
+	assert.Empty(t, filteredLog(t, dir, &Filter{Types: []conventional_commits.ChangeType{conventional_commits.FIX}, Overrides: commitOverrides}))
+}
+
+func TestVersionLog_should_filter_author_grep_and_paths(t *testing.T) {
+	dir := initFilterHistory(t)
+
+	assert.Len(t, filteredLog(t, dir, &Filter{Author: "test@example"}), 4)
+	assert.Empty(t, filteredLog(t, dir, &Filter{Author: "^someone"}))
+	assert.Equal(t, []string{"Update readme", "fix(ui-button): Fix button"}, filteredLog(t, dir, &Filter{Grep: "(?i)^(update|fix button)"}))
+	assert.Equal(t, []string{"fix(api): Fix handler", "feat(api)!: Replace handler"}, filteredLog(t, dir, &Filter{Paths: []string{"api"}}))
+	assert.Equal(t, []string{"fix(ui-button): Fix button"}, filteredLog(t, dir, &Filter{Paths: []string{"/ui/*.ts"}}))
+}
+
+func TestRangeLog_should_filter_commits(t *testing.T) {
+	dir := initFilterHistory(t)
+
+	commits, err := RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "1.0.0"}, Filter: &Filter{Paths: []string{"ui"}}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix(ui-button): Fix button"}, messages(commits))
+}
+
+func TestVersionLog_should_fail_on_invalid_filter_patterns(t *testing.T) {
+	dir := initFilterHistory(t)
+
+	_, err := VersionLog(VersionLogOptions{Workdir: dir, Filter: &Filter{Grep: "("}})
+	assert.ErrorContains(t, err, "Could not parse grep pattern (")
+
+	_, err = VersionLog(VersionLogOptions{Workdir: dir, Filter: &Filter{Scopes: []string{"["}}})
+	assert.ErrorContains(t, err, "Could not parse pattern [")
+}
+
+func TestVersionLog_should_remove_ignored_commits(t *testing.T) {
+	dir := initFilterHistory(t)
+
+	rules, err := ignore.NewRules(&config.IgnoreConfig{Messages: []string{"^Update"}, Footers: []string{"Skip-Release"}})
+	assert.Nil(t, err)
+
+	commits, err := VersionLog(VersionLogOptions{Workdir: dir, Ignore: rules, Filter: &Filter{Types: []conventional_commits.ChangeType{conventional_commits.FIX}}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix(api): Fix handler", "fix(ui-button): Fix button"}, messages(commits))
+
+	commits, err = RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "1.0.0"}, Ignore: rules})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix(api): Fix handler", "fix(ui-button): Fix button", "feat(api)!: Replace handler"}, messages(commits))
+}
@@ -0,0 +1,163 @@
+package version_log
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/semver"
+	"strings"
+)
+
+// Range is a range of commits like the revision ranges of git. The endpoints may be versions, tags, branches or commit
+// hashes. An empty endpoint means HEAD.
+type Range struct {
+	From string
+	To   string
+	// Symmetric selects the commits, which are reachable from either From or To, but not from both ("<from>...<to>").
+	// Otherwise the commits reachable from To, but not from From are selected ("<from>..<to>").
+	Symmetric bool
+}
+
+type RangeLogOptions struct {
+	Workdir string
+	Range   *Range
+	// Filter selects the returned commits. All commits are returned if it is nil.
+	Filter *Filter
+	// Ignore removes matching commits. No commits are ignored if it is nil.
+	Ignore *ignore.Rules
+}
+
+// Parses "<from>..<to>" or "<from>...<to>". Returns false if str is no range.
+func ParseRange(str string) (*Range, bool) {
+
+	if from, to, ok := strings.Cut(str, "..."); ok {
+		return &Range{From: from, To: to, Symmetric: true}, true
+	}
+
+	if from, to, ok := strings.Cut(str, ".."); ok {
+		return &Range{From: from, To: to}, true
+	}
+
+	return nil, false
+}
+
+// Returns the endpoint or HEAD if it is empty.
+func (r *Range) FromOrHead() string {
+	return orHead(r.From)
+}
+
+// Returns the endpoint or HEAD if it is empty.
+func (r *Range) ToOrHead() string {
+	return orHead(r.To)
+}
+
+func orHead(revision string) string {
+	if revision == "" {
+		return "HEAD"
+	}
+
+	return revision
+}
+
+func (r *Range) String() string {
+	if r.Symmetric {
+		return r.From + "..." + r.To
+	}
+
+	return r.From + ".." + r.To
+}
+
+// Returns the commits of the range. Most recent commits are returned first.
+func RangeLog(options RangeLogOptions) ([]*object.Commit, error) {
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	from, err := resolveRevision(repo, options.Range.FromOrHead())
+
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := resolveRevision(repo, options.Range.ToOrHead())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+
+	if options.Range.Symmetric {
+		commits, err = symmetricDifference(repo, from, to)
+	} else {
+		commits, err = commitRange(repo, []plumbing.Hash{to.Hash}, []plumbing.Hash{from.Hash})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, _ = options.Ignore.Filter(commits)
+
+	return filterCommits(commits, options.Filter)
+}
+
+// Returns the commits reachable from either from or to, but not from both.
+func symmetricDifference(repo *git.Repository, from *object.Commit, to *object.Commit) ([]*object.Commit, error) {
+
+	mergeBases, err := from.MergeBase(to)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not find merge base of "+from.Hash.String()+" and "+to.Hash.String())
+	}
+
+	var excluded []plumbing.Hash
+
+	for _, mergeBase := range mergeBases {
+		excluded = append(excluded, mergeBase.Hash)
+	}
+
+	return commitRange(repo, []plumbing.Hash{from.Hash, to.Hash}, excluded)
+}
+
+// Resolves a revision (e.g. a tag, branch or commit hash) or a version, which is resolved to its tag with or without
+// "v" prefix.
+func resolveRevision(repo *git.Repository, revision string) (*object.Commit, error) {
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+
+	if err != nil {
+		version, versionErr := semver.ParseVersion(revision)
+
+		if versionErr != nil {
+			return nil, errors.WithMessage(err, "Could not resolve revision "+revision)
+		}
+
+		tag, tagErr := findTagForVersion(repo, version.ToString())
+
+		if tagErr != nil {
+			return nil, tagErr
+		}
+
+		hash, err = repo.ResolveRevision(plumbing.Revision(tag.Name()))
+
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not resolve revision "+revision)
+		}
+	}
+
+	commit, err := repo.CommitObject(*hash)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read commit "+hash.String())
+	}
+
+	return commit, nil
+}
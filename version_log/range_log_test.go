@@ -0,0 +1,89 @@
+package version_log
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func messages(commits []*object.Commit) []string {
+	var ret []string
+
+	for _, commit := range commits {
+		ret = append(ret, strings.TrimSpace(commit.Message))
+	}
+
+	return ret
+}
+
+func TestParseRange_should_parse_two_and_three_dot_ranges(t *testing.T) {
+	r, ok := ParseRange("1.0.0..v2.0.0")
+	assert.True(t, ok)
+	assert.Equal(t, &Range{From: "1.0.0", To: "v2.0.0"}, r)
+
+	r, ok = ParseRange("main...")
+	assert.True(t, ok)
+	assert.Equal(t, &Range{From: "main", Symmetric: true}, r)
+	assert.Equal(t, "HEAD", r.ToOrHead())
+
+	_, ok = ParseRange("1.0.0")
+	assert.False(t, ok)
+}
+
+func TestRangeLog_should_return_commits_between_versions(t *testing.T) {
+	dir := initHistory(t)
+
+	commits, err := RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "1.0.0", To: "1.1.0"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix: C", "feat: B"}, messages(commits))
+
+	commits, err = RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "v1.1.0-rc.1"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix: E", "fix: D", "fix: C"}, messages(commits))
+}
+
+func TestRangeLog_should_support_branches_hashes_and_symmetric_ranges(t *testing.T) {
+	repo, dir := test_utils.InitRepo(t)
+
+	base := test_utils.Commit(t, repo, "feat: Base")
+	test_utils.Commit(t, repo, "feat: Main")
+
+	worktree, err := repo.Worktree()
+	assert.Nil(t, err)
+
+	assert.Nil(t, worktree.Checkout(&git.CheckoutOptions{Hash: base, Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	test_utils.Commit(t, repo, "feat: Feature")
+
+	commits, err := RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "master", To: "feature"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"feat: Feature"}, messages(commits))
+
+	commits, err = RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "master", To: "feature", Symmetric: true}})
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"feat: Feature", "feat: Main"}, messages(commits))
+
+	commits, err = RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{To: base.String()[:7]}})
+
+	assert.Nil(t, err)
+	assert.Empty(t, commits)
+}
+
+func TestRangeLog_should_fail_on_unknown_revision(t *testing.T) {
+	dir := initHistory(t)
+
+	_, err := RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "unknown"}})
+
+	assert.ErrorContains(t, err, "Could not resolve revision unknown")
+
+	_, err = RangeLog(RangeLogOptions{Workdir: dir, Range: &Range{From: "3.0.0"}})
+
+	assert.ErrorContains(t, err, "Could not find tag 3.0.0 or v3.0.0")
+}
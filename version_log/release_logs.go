@@ -0,0 +1,229 @@
+package version_log
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/semver"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ReleaseLogsOptions struct {
+	Workdir string
+	// Constraint filters the returned releases. All releases are returned if it is nil.
+	Constraint *semver.Constraint
+	// Since filters the returned releases to this and all greater versions. All releases are returned if it is nil.
+	Since *semver.Version
+	// SeparatePreReleases returns a release log for each pre-release. Otherwise the commits of pre-releases are
+	// contained in the following release. Pre-releases without a following release are always returned separately.
+	SeparatePreReleases bool
+	// Ignore removes matching commits. No commits are ignored if it is nil.
+	Ignore *ignore.Rules
+}
+
+// ReleaseLog contains the commits of a single release
+type ReleaseLog struct {
+	// Version is nil for the unreleased commits
+	Version *semver.Version
+	TagName string
+	// PreviousTagName is the tag of the preceding release. It is empty for the first release.
+	PreviousTagName string
+	// Date is the tagger date of annotated tags or the committer date of the tagged commit
+	Date time.Time
+	// Commits are the commits since the preceding release. Most recent commits are first.
+	Commits []*object.Commit
+}
+
+type versionTag struct {
+	version *semver.Version
+	ref     *plumbing.Reference
+	commit  *object.Commit
+	date    time.Time
+}
+
+// Returns a release log for each version tag and for the unreleased commits on HEAD. The unreleased commits are only
+// returned if there are any. The most recent release is returned first.
+func ReleaseLogs(options ReleaseLogsOptions) ([]*ReleaseLog, error) {
+
+	repo, err := git.PlainOpenWithOptions(options.Workdir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not open git repository")
+	}
+
+	tags, err := findVersionTags(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.SeparatePreReleases {
+		tags = withoutFoldedPreReleases(tags)
+	}
+
+	var logs []*ReleaseLog
+	var excluded []plumbing.Hash
+	var previousTagName string
+
+	for _, tag := range tags {
+		commits, err := commitRange(repo, []plumbing.Hash{tag.commit.Hash}, excluded)
+
+		if err != nil {
+			return nil, err
+		}
+
+		commits, _ = options.Ignore.Filter(commits)
+		excluded = []plumbing.Hash{tag.commit.Hash}
+		tagName := tag.ref.Name().Short()
+		precedingTagName := previousTagName
+		previousTagName = tagName
+
+		if options.Since != nil && semver.CompareVersions(tag.version, options.Since) < 0 ||
+			options.Constraint != nil && !options.Constraint.Check(tag.version) {
+			continue
+		}
+
+		logs = append([]*ReleaseLog{{
+			Version:         tag.version,
+			TagName:         tagName,
+			PreviousTagName: precedingTagName,
+			Date:            tag.date,
+			Commits:         commits,
+		}}, logs...)
+	}
+
+	head, err := repo.Head()
+
+	if err == plumbing.ErrReferenceNotFound {
+		return logs, nil
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "Could not find HEAD")
+	}
+
+	unreleasedCommits, err := commitRange(repo, []plumbing.Hash{head.Hash()}, excluded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unreleasedCommits, _ = options.Ignore.Filter(unreleasedCommits)
+
+	if len(unreleasedCommits) > 0 {
+		logs = append([]*ReleaseLog{{PreviousTagName: previousTagName, Commits: unreleasedCommits}}, logs...)
+	}
+
+	return logs, nil
+}
+
+// Returns all version tags ordered by version. If there are multiple tags for the same version (e.g. "1.0.0" and
+// "v1.0.0"), the tag with "v" prefix is used.
+func findVersionTags(repo *git.Repository) ([]*versionTag, error) {
+
+	tagIter, err := repo.Tags()
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not find Tags")
+	}
+
+	defer tagIter.Close()
+
+	var tags []*versionTag
+
+	for ref, err := tagIter.Next(); err != io.EOF; ref, err = tagIter.Next() {
+		if err != nil {
+			return nil, errors.WithMessage(err, "Could not find Tags")
+		}
+
+		version, err := semver.ParseVersion(ref.Name().Short())
+
+		if err != nil {
+			continue
+		}
+
+		tag, err := resolveVersionTag(repo, version, ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		comparison := semver.CompareVersions(tags[i].version, tags[j].version)
+
+		if comparison == 0 {
+			return strings.HasPrefix(tags[i].ref.Name().Short(), "v")
+		}
+
+		return comparison < 0
+	})
+
+	var distinct []*versionTag
+
+	for _, tag := range tags {
+		if len(distinct) > 0 && semver.CompareVersions(distinct[len(distinct)-1].version, tag.version) == 0 {
+			continue
+		}
+
+		distinct = append(distinct, tag)
+	}
+
+	return distinct, nil
+}
+
+func resolveVersionTag(repo *git.Repository, version *semver.Version, ref *plumbing.Reference) (*versionTag, error) {
+
+	tag := &versionTag{version: version, ref: ref}
+
+	tagObject, err := repo.TagObject(ref.Hash())
+
+	switch err {
+	case nil:
+		tag.date = tagObject.Tagger.When
+		tag.commit, err = tagObject.Commit()
+	case plumbing.ErrObjectNotFound:
+		tag.commit, err = repo.CommitObject(ref.Hash())
+
+		if err == nil {
+			tag.date = tag.commit.Committer.When
+		}
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not resolve tag "+ref.Name().Short())
+	}
+
+	return tag, nil
+}
+
+// Removes all pre-releases, which precede a release.
+func withoutFoldedPreReleases(tags []*versionTag) []*versionTag {
+
+	var greatestRelease *semver.Version
+
+	for _, tag := range tags {
+		if !tag.version.IsPreRelease() {
+			greatestRelease = tag.version
+		}
+	}
+
+	var ret []*versionTag
+
+	for _, tag := range tags {
+		if tag.version.IsPreRelease() && semver.CompareVersions(tag.version, greatestRelease) < 0 {
+			continue
+		}
+
+		ret = append(ret, tag)
+	}
+
+	return ret
+}
@@ -0,0 +1,123 @@
+package version_log
+
+import (
+	"github.com/psanetra/git-semver/config"
+	"github.com/psanetra/git-semver/ignore"
+	"github.com/psanetra/git-semver/semver"
+	"github.com/psanetra/git-semver/test_utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func summarize(logs []*ReleaseLog) map[string][]string {
+	ret := make(map[string][]string)
+
+	for _, log := range logs {
+		label := "unreleased"
+
+		if log.Version != nil {
+			label = log.TagName
+		}
+
+		ret[label] = []string{}
+
+		for _, commit := range log.Commits {
+			ret[label] = append(ret[label], commit.Message)
+		}
+	}
+
+	return ret
+}
+
+func labels(logs []*ReleaseLog) []string {
+	var ret []string
+
+	for _, log := range logs {
+		if log.Version == nil {
+			ret = append(ret, "unreleased")
+		} else {
+			ret = append(ret, log.TagName)
+		}
+	}
+
+	return ret
+}
+
+func initHistory(t *testing.T) string {
+	repo, dir := test_utils.InitRepo(t)
+
+	test_utils.Commit(t, repo, "feat: A")
+	test_utils.Tag(t, repo, "v1.0.0")
+	test_utils.Commit(t, repo, "feat: B")
+	test_utils.Tag(t, repo, "v1.1.0-rc.1")
+	test_utils.Commit(t, repo, "fix: C")
+	test_utils.AnnotatedTag(t, repo, "v1.1.0", "Release 1.1.0")
+	test_utils.Commit(t, repo, "fix: D")
+	test_utils.Tag(t, repo, "v1.1.1-rc.1")
+	test_utils.Commit(t, repo, "fix: E")
+
+	return dir
+}
+
+func TestReleaseLogs_should_fold_pre_releases_into_following_release(t *testing.T) {
+	dir := initHistory(t)
+
+	logs, err := ReleaseLogs(ReleaseLogsOptions{Workdir: dir})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"unreleased", "v1.1.1-rc.1", "v1.1.0", "v1.0.0"}, labels(logs))
+	assert.Equal(t, map[string][]string{
+		"unreleased":  {"fix: E"},
+		"v1.1.1-rc.1": {"fix: D"},
+		"v1.1.0":      {"fix: C", "feat: B"},
+		"v1.0.0":      {"feat: A"},
+	}, summarize(logs))
+	assert.True(t, test_utils.Signature.When.Equal(logs[2].Date))
+	assert.Equal(t, "v1.1.1-rc.1", logs[0].PreviousTagName)
+	assert.Equal(t, "v1.0.0", logs[2].PreviousTagName)
+	assert.Equal(t, "", logs[3].PreviousTagName)
+}
+
+func TestReleaseLogs_should_return_separate_pre_releases(t *testing.T) {
+	dir := initHistory(t)
+
+	logs, err := ReleaseLogs(ReleaseLogsOptions{Workdir: dir, SeparatePreReleases: true})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"unreleased", "v1.1.1-rc.1", "v1.1.0", "v1.1.0-rc.1", "v1.0.0"}, labels(logs))
+	assert.Equal(t, []string{"fix: C"}, summarize(logs)["v1.1.0"])
+}
+
+func TestReleaseLogs_should_filter_versions(t *testing.T) {
+	dir := initHistory(t)
+
+	constraint, err := semver.ParseConstraint("<1.1.1-rc.2")
+	assert.Nil(t, err)
+
+	logs, err := ReleaseLogs(ReleaseLogsOptions{
+		Workdir:    dir,
+		Constraint: constraint,
+		Since:      &semver.Version{Major: 1, Minor: 1},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"unreleased", "v1.1.1-rc.1", "v1.1.0"}, labels(logs))
+	assert.Equal(t, map[string][]string{
+		"unreleased":  {"fix: E"},
+		"v1.1.1-rc.1": {"fix: D"},
+		"v1.1.0":      {"fix: C", "feat: B"},
+	}, summarize(logs))
+}
+
+func TestReleaseLogs_should_remove_ignored_commits(t *testing.T) {
+	dir := initHistory(t)
+
+	rules, err := ignore.NewRules(&config.IgnoreConfig{Messages: []string{"^fix: (C|E)$"}})
+	assert.Nil(t, err)
+
+	logs, err := ReleaseLogs(ReleaseLogsOptions{Workdir: dir, Ignore: rules})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"v1.1.1-rc.1", "v1.1.0", "v1.0.0"}, labels(logs))
+	assert.Equal(t, []string{"feat: B"}, summarize(logs)["v1.1.0"])
+}
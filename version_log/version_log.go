@@ -7,6 +7,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/revlist"
 	"github.com/pkg/errors"
 	"github.com/psanetra/git-semver/git_utils"
+	"github.com/psanetra/git-semver/ignore"
 	"github.com/psanetra/git-semver/latest"
 	"github.com/psanetra/git-semver/logger"
 	"github.com/psanetra/git-semver/semver"
@@ -17,6 +18,10 @@ type VersionLogOptions struct {
 	Workdir                  string
 	Version                  *semver.Version
 	ExcludePreReleaseCommits bool
+	// Filter selects the returned commits. All commits are returned if it is nil.
+	Filter *Filter
+	// Ignore removes matching commits. No commits are ignored if it is nil.
+	Ignore *ignore.Rules
 }
 
 // Returns all commits since the preceding version to options.Version
@@ -81,13 +86,52 @@ func VersionLog(options VersionLogOptions) ([]*object.Commit, error) {
 		excludedCommits = append(excludedCommits, fromVersionTag.Hash())
 	}
 
+	commits, err := commitRange(repo, []plumbing.Hash{targetVersionRef.Hash()}, excludedCommits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, _ = options.Ignore.Filter(commits)
+
+	return filterCommits(commits, options.Filter)
+}
+
+// Returns the commits since the version tag (exclusive) up to HEAD without the ignored commits. All commits of HEAD are
+// returned if tag is nil. Most recent commits are returned first.
+func CommitsSince(repo *git.Repository, tag *plumbing.Reference, ignoreRules *ignore.Rules) ([]*object.Commit, error) {
+
+	headRef, err := repo.Head()
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not find HEAD")
+	}
+
+	var excludedCommits []plumbing.Hash
+
+	if tag != nil {
+		excludedCommits = append(excludedCommits, tag.Hash())
+	}
+
+	commits, err := commitRange(repo, []plumbing.Hash{headRef.Hash()}, excludedCommits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, _ = ignoreRules.Filter(commits)
+
+	return commits, nil
+}
+
+// Returns all commits reachable from targets, but not from excluded. Most recent commits are returned first.
+func commitRange(repo *git.Repository, targets []plumbing.Hash, excluded []plumbing.Hash) ([]*object.Commit, error) {
+
 	// historyRange also contains other hashes than commit hashes (e.g. blob or tree hashes)
 	historyRange, err := revlist.Objects(
 		repo.Storer,
-		[]plumbing.Hash{
-			targetVersionRef.Hash(),
-		},
-		excludedCommits,
+		targets,
+		excluded,
 	)
 
 	if err != nil {